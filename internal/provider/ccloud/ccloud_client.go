@@ -23,7 +23,14 @@ type CcloudClient struct {
 	sqlConMap  map[string]map[string]*pgx.ConnPool
 }
 
-const clusterUserName = "terraform-provider-cockroach-extra"
+// ClusterUserName is the name of the temporary SQL user the provider connects as.
+const ClusterUserName = "terraform-provider-cockroach-extra"
+
+// cleanupTimeout bounds the statements run after a handler, which may outlive the handler context.
+const cleanupTimeout = 10 * time.Second
+
+// sessionSetupTimeout bounds the statements run on every new pooled connection.
+const sessionSetupTimeout = 10 * time.Second
 
 var userCredMapResource = NewSyncResourceHolder(&UserCredMap{})
 
@@ -114,14 +121,14 @@ func generateAuthHeader(apiKey string) string {
 }
 
 func (c *CcloudClient) createTempUser(ctx context.Context, clusterId string) (user *tempUser, err error) {
-	err = c.deleteTempUser(ctx, clusterId, clusterUserName)
+	err = c.deleteTempUser(ctx, clusterId, ClusterUserName)
 	if err != nil {
 		return nil, err
 	}
 
 	path := fmt.Sprintf("/api/v1/clusters/%s/sql-users", clusterId)
 	request := tempUser{
-		Username: clusterUserName,
+		Username: ClusterUserName,
 		Password: uuid.New().String(),
 	}
 
@@ -134,7 +141,7 @@ func (c *CcloudClient) createTempUser(ctx context.Context, clusterId string) (us
 
 	// Create a temp sql user using the ccloud api
 	tflog.Debug(ctx, fmt.Sprintf("Making POST request to: %s", c.Host+path))
-	req, err := http.NewRequest("POST", c.Host+path, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Host+path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +183,7 @@ func (c *CcloudClient) updateUserExpiration(ctx context.Context, clusterId strin
 		return err
 	}
 	expTime := time.Now().Add(30 * time.Minute)
-	_, err = pool.Exec(fmt.Sprintf("ALTER USER %s WITH VALID UNTIL $1", pgx.Identifier{user.Username}.Sanitize()), expTime.Format(time.RFC3339))
+	_, err = pool.ExecEx(ctx, fmt.Sprintf("ALTER USER %s WITH VALID UNTIL $1", pgx.Identifier{user.Username}.Sanitize()), nil, expTime.Format(time.RFC3339))
 	return err
 }
 
@@ -195,10 +202,10 @@ func (c *CcloudClient) getOrCreateTempUser(ctx context.Context, userCredMap *Use
 	return credMap[clusterId], nil
 }
 
-func (c *CcloudClient) deleteTempUser(_ context.Context, clusterId string, username string) (err error) {
+func (c *CcloudClient) deleteTempUser(ctx context.Context, clusterId string, username string) (err error) {
 	path := fmt.Sprintf("/api/v1/clusters/%s/sql-users/%s", clusterId, username)
 
-	req, err := http.NewRequest("DELETE", c.Host+path, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.Host+path, nil)
 	if err != nil {
 		return err
 	}
@@ -241,7 +248,7 @@ type ConnectionStringResponse struct {
 
 func (c *CcloudClient) getConnectionOptions(ctx context.Context, clusterId string, user *tempUser, database string) (con *pgx.ConnConfig, err error) {
 	path := fmt.Sprintf("/api/v1/clusters/%s/connection-string?sql_user=%s", clusterId, user.Username)
-	req, err := http.NewRequest("GET", c.Host+path, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.Host+path, nil)
 	if err != nil {
 
 		return nil, err
@@ -308,7 +315,10 @@ func (c *CcloudClient) getOrCreateConPool(ctx context.Context, clusterId string,
 			ConnConfig:     *connConfig,
 			MaxConnections: 5,
 			AfterConnect: func(conn *pgx.Conn) error {
-				_, err := conn.Exec("SET role admin")
+				// The pool outlives this request and opens connections for later ones, so only the deadline is bounded here
+				setupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sessionSetupTimeout)
+				defer cancel()
+				_, err := conn.ExecEx(setupCtx, "SET role admin", nil)
 				return err
 			},
 		}
@@ -339,6 +349,11 @@ func SqlConWithTempUser[Handler func(db *pgx.ConnPool) (*R, error), R any](ctx c
 	userCredMap, unlock := userCredMapResource.Get()
 	defer unlock()
 
+	// The context may have expired while waiting for the lock
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	user, err := client.getOrCreateTempUser(ctx, userCredMap, clusterId)
 	if err != nil {
 		return nil, err
@@ -355,7 +370,10 @@ func SqlConWithTempUser[Handler func(db *pgx.ConnPool) (*R, error), R any](ctx c
 	}
 
 	defer func(pool *pgx.ConnPool, sql string) {
-		_, err := pool.Exec(sql)
+		// Hand over objects created by the temp user even when ctx was cancelled, but do not hang on it
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		_, err := pool.ExecEx(cleanupCtx, sql, nil)
 		if err != nil {
 			return
 		}
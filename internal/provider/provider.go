@@ -92,14 +92,21 @@ func (p *CockroachExtraProvider) Configure(ctx context.Context, req provider.Con
 func (p *CockroachExtraProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewClusterSettingResource,
+		resources.NewClusterSettingsResource,
+		resources.NewZoneConfigResource,
+		resources.NewRowLevelTtlResource,
 		resources.NewRoleGrantResource,
+		resources.NewRoleGrantsResource,
 		resources.NewSqlUserResource,
+		resources.NewRotatingSqlUserResource,
 		resources.NewSqlRoleResource,
 		resources.NewMigrationResource,
 		resources.NewExternalConnectionResource,
 		resources.NewChangefeedResource,
+		resources.NewChangefeedScheduleResource,
 		resources.NewPersistentCursorResource,
 		resources.NewBackupScheduleResource,
+		resources.NewSqlScheduleResource,
 	}
 }
 
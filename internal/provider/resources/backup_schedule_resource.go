@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
 	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
@@ -31,6 +32,7 @@ import (
 )
 
 var _ resource.Resource = &BackupScheduleResource{}
+var _ resource.ResourceWithImportState = &BackupScheduleResource{}
 
 func NewBackupScheduleResource() resource.Resource {
 	return &BackupScheduleResource{}
@@ -58,8 +60,10 @@ type BackupScheduleResourceModel struct {
 		OnPreviousRunning     types.String `tfsdk:"on_previous_running"`
 		IgnoreExistingBackups types.Bool   `tfsdk:"ignore_existing_backups"`
 	} `tfsdk:"schedule_options"`
-	FullBackupScheduleId        types.Int64 `tfsdk:"full_backup_schedule_id"`
-	IncrementalBackupScheduleId types.Int64 `tfsdk:"incremental_backup_schedule_id"`
+	FullBackupScheduleId        types.Int64  `tfsdk:"full_backup_schedule_id"`
+	IncrementalBackupScheduleId types.Int64  `tfsdk:"incremental_backup_schedule_id"`
+	Paused                      types.Bool   `tfsdk:"paused"`
+	NextRun                     types.String `tfsdk:"next_run"`
 	BackupOptions               *struct {
 		Kms                       types.String `tfsdk:"kms"`
 		EncryptionPassphrase      types.String `tfsdk:"encryption_passphrase"`
@@ -67,6 +71,7 @@ type BackupScheduleResourceModel struct {
 		FullBackupFrequency       types.String `tfsdk:"full_backup_frequency"`
 		IncrementalBackupLocation types.String `tfsdk:"incremental_backup_location"`
 	} `tfsdk:"backup_options"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *BackupScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -245,6 +250,16 @@ func (r *BackupScheduleResource) Schema(ctx context.Context, req resource.Schema
 				Required:            false,
 				Computed:            true,
 			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Whether the full and incremental backup schedules are paused",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"next_run": schema.StringAttribute{
+				MarkdownDescription: "Next time a backup runs, empty when the schedule is paused",
+				Computed:            true,
+			},
 			"backup_options": schema.SingleNestedAttribute{
 				MarkdownDescription: "Backup options",
 				Optional:            true,
@@ -302,6 +317,9 @@ func (r *BackupScheduleResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -331,11 +349,14 @@ func (r *BackupScheduleResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	// Check if backup schedule with the given label already exists
 	// "IF NOT EXISTS" should prevent duplication, but an explicit check prevents confusion
 	scheduleExists, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
 		var exists bool
-		err := db.QueryRow("SELECT EXISTS(SELECT * FROM [SHOW schedules for backup ] WHERE label = $1)", data.Label.ValueString()).Scan(&exists)
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT * FROM [SHOW schedules for backup ] WHERE label = $1)", nil, data.Label.ValueString()).Scan(&exists)
 		return &exists, err
 	})
 
@@ -393,19 +414,7 @@ func (r *BackupScheduleResource) Create(ctx context.Context, req resource.Create
 
 	tflog.Debug(ctx, fmt.Sprintf("Backup options: %s", backupOptions))
 
-	scheduleOptionsSet := []string{}
-
-	if !data.ScheduleOptions.FirstRun.IsNull() {
-		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("first_run=%s", SanatizeValue(data.ScheduleOptions.FirstRun.ValueString())))
-	}
-
-	if !data.ScheduleOptions.OnExecutionFailure.IsNull() {
-		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("on_execution_failure=%s", SanatizeValue(data.ScheduleOptions.OnExecutionFailure.ValueString())))
-	}
-
-	if !data.ScheduleOptions.OnPreviousRunning.IsNull() {
-		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("on_previous_running=%s", SanatizeValue(data.ScheduleOptions.OnPreviousRunning.ValueString())))
-	}
+	scheduleOptionsSet := buildScheduleOptions(data.ScheduleOptions.FirstRun, data.ScheduleOptions.OnExecutionFailure, data.ScheduleOptions.OnPreviousRunning)
 
 	if !data.ScheduleOptions.IgnoreExistingBackups.IsNull() && data.ScheduleOptions.IgnoreExistingBackups.ValueBool() {
 		scheduleOptionsSet = append(scheduleOptionsSet, "ignore_existing_backups")
@@ -441,7 +450,7 @@ func (r *BackupScheduleResource) Create(ctx context.Context, req resource.Create
 
 	scheduleIds, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*scheduleIdSet, error) {
 		schedules := scheduleIdSet{}
-		rows, err := db.Query(fullQuery)
+		rows, err := db.QueryEx(ctx, fullQuery, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -482,45 +491,93 @@ func (r *BackupScheduleResource) Create(ctx context.Context, req resource.Create
 	}
 	data.FullBackupScheduleId = types.Int64Value(*scheduleIds.fullBackupId)
 
+	nextRun, err := r.setBackupSchedulePaused(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to set backup schedule paused state", err.Error())
+		return
+	}
+	data.NextRun = *nextRun
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data BackupScheduleResourceModel
+// setBackupSchedulePaused pauses or resumes the full and incremental schedules and returns the next time either of them runs.
+func (r *BackupScheduleResource) setBackupSchedulePaused(ctx context.Context, data *BackupScheduleResourceModel) (*types.String, error) {
+	scheduleIds := []int64{data.FullBackupScheduleId.ValueInt64()}
+	if !data.IncrementalBackupScheduleId.IsNull() {
+		scheduleIds = append(scheduleIds, data.IncrementalBackupScheduleId.ValueInt64())
+	}
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	return ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*types.String, error) {
+		var statuses []*scheduleStatus
+		for _, scheduleId := range scheduleIds {
+			status, err := getScheduleStatusById(ctx, db, scheduleId)
+			if err != nil {
+				return nil, err
+			}
+			if status.Paused() != data.Paused.ValueBool() {
+				tflog.Debug(ctx, fmt.Sprintf("Setting paused=%t on schedule %d", data.Paused.ValueBool(), scheduleId))
+				if err := execSetSchedulePaused(ctx, db, scheduleId, data.Paused.ValueBool()); err != nil {
+					return nil, err
+				}
+				if status, err = getScheduleStatusById(ctx, db, scheduleId); err != nil {
+					return nil, err
+				}
+			}
+			statuses = append(statuses, status)
+		}
+		nextRun := backupScheduleNextRun(statuses)
+		return &nextRun, nil
+	})
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+// backupScheduleNextRun returns the earliest next run of the schedules.
+func backupScheduleNextRun(statuses []*scheduleStatus) types.String {
+	var earliest *scheduleStatus
+	for _, status := range statuses {
+		if status.NextRun != nil && (earliest == nil || status.NextRun.Before(*earliest.NextRun)) {
+			earliest = status
+		}
 	}
-
-	type scheduleInfo struct {
-		id                 int64
-		label              string
-		recurrence         string
-		onPreviousRunning  string
-		onExecutionFailure string
-		command            *tree.Backup
-		backupType         string
+	if earliest == nil {
+		return types.StringNull()
 	}
+	return earliest.NextRunValue()
+}
 
-	type scheduleSet struct {
-		fullBackup        *scheduleInfo
-		incrementalBackup *scheduleInfo
-	}
+type backupScheduleInfo struct {
+	id                 int64
+	label              string
+	recurrence         string
+	onPreviousRunning  string
+	onExecutionFailure string
+	command            *tree.Backup
+	backupType         string
+	status             *scheduleStatus
+}
+
+type backupScheduleSet struct {
+	fullBackup        *backupScheduleInfo
+	incrementalBackup *backupScheduleInfo
+}
 
-	schedules, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*scheduleSet, error) {
-		schedules := scheduleSet{}
-		rows, err := db.Query("SELECT id, label, recurrence, on_previous_running, on_execution_failure, command, backup_type FROM [SHOW SCHEDULES FOR BACKUP] WHERE label = $1", data.Label.ValueString())
+// getBackupSchedules reads the full and incremental schedules with the label.
+func (r *BackupScheduleResource) getBackupSchedules(ctx context.Context, clusterId string, label string) (*backupScheduleSet, error) {
+	return ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*backupScheduleSet, error) {
+		schedules := backupScheduleSet{}
+		rows, err := db.QueryEx(ctx, "SELECT id, label, recurrence, on_previous_running, on_execution_failure, command, backup_type, schedule_status, next_run FROM [SHOW SCHEDULES FOR BACKUP] WHERE label = $1", nil, label)
 		if err != nil {
 			return nil, err
 		}
 
+		defer rows.Close()
+
 		for rows.Next() {
 			var scheduleId int64
 			var label, recurrence, onPreviousRunning, onExecutionFailure, command, backupType string
+			status := scheduleStatus{}
 
-			err = rows.Scan(&scheduleId, &label, &recurrence, &onPreviousRunning, &onExecutionFailure, &command, &backupType)
+			err = rows.Scan(&scheduleId, &label, &recurrence, &onPreviousRunning, &onExecutionFailure, &command, &backupType, &status.Status, &status.NextRun)
 			if err != nil {
 				return nil, err
 			}
@@ -534,7 +591,7 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 			}
 
 			if backupType == "FULL" {
-				schedules.fullBackup = &scheduleInfo{
+				schedules.fullBackup = &backupScheduleInfo{
 					id:                 scheduleId,
 					label:              label,
 					recurrence:         recurrence,
@@ -542,9 +599,10 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 					onExecutionFailure: onExecutionFailure,
 					command:            backupCommand,
 					backupType:         backupType,
+					status:             &status,
 				}
 			} else {
-				schedules.incrementalBackup = &scheduleInfo{
+				schedules.incrementalBackup = &backupScheduleInfo{
 					id:                 scheduleId,
 					label:              label,
 					recurrence:         recurrence,
@@ -552,44 +610,47 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 					onExecutionFailure: onExecutionFailure,
 					command:            backupCommand,
 					backupType:         backupType,
+					status:             &status,
 				}
 			}
 		}
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
 		return &schedules, nil
 	})
+}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to read backup schedule", err.Error())
-		return
-	}
-
-	if schedules.fullBackup == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	data.Id = types.StringValue(schedules.fullBackup.label)
+// applyBackupSchedules updates data from the full and incremental schedules.
+func (data *BackupScheduleResourceModel) applyBackupSchedules(schedules *backupScheduleSet) {
+	data.Id = types.StringValue(getBackupScheduleId(data.ClusterId.ValueString(), schedules.fullBackup.label))
 	if schedules.incrementalBackup != nil {
 		data.Recurring = types.StringValue(schedules.incrementalBackup.recurrence)
 	} else {
 		data.Recurring = types.StringValue(schedules.fullBackup.recurrence)
 	}
 
-	data.ScheduleOptions.OnPreviousRunning = types.StringValue(strings.ToLower(schedules.fullBackup.onPreviousRunning))
+	data.ScheduleOptions.OnPreviousRunning = types.StringValue(scheduleOnPreviousRunning(schedules.fullBackup.onPreviousRunning))
+	data.ScheduleOptions.OnExecutionFailure = types.StringValue(scheduleOnExecutionFailure(schedules.fullBackup.onExecutionFailure))
 
-	mapOnExecutionFailure := map[string]string{
-		"PAUSE_SCHED": "pause",
-		"RETRY_SOON":  "retry",
-		"RETRY_SCHED": "reschedule",
-	}
+	data.FullBackupScheduleId = types.Int64Value(schedules.fullBackup.id)
 
-	if val, ok := mapOnExecutionFailure[schedules.fullBackup.onExecutionFailure]; ok {
-		data.ScheduleOptions.OnExecutionFailure = types.StringValue(val)
+	statuses := []*scheduleStatus{schedules.fullBackup.status}
+	if schedules.incrementalBackup != nil {
+		statuses = append(statuses, schedules.incrementalBackup.status)
+	}
+	allPaused, anyPaused := true, false
+	for _, status := range statuses {
+		allPaused = allPaused && status.Paused()
+		anyPaused = anyPaused || status.Paused()
+	}
+	if allPaused == anyPaused {
+		data.Paused = types.BoolValue(allPaused)
 	} else {
-		data.ScheduleOptions.OnExecutionFailure = types.StringValue("retry")
+		// Only one of the schedules is paused, report the opposite of the configuration so the next apply fixes it
+		data.Paused = types.BoolValue(!data.Paused.ValueBool())
 	}
-
-	data.FullBackupScheduleId = types.Int64Value(schedules.fullBackup.id)
+	data.NextRun = backupScheduleNextRun(statuses)
 
 	if schedules.incrementalBackup == nil {
 		data.BackupOptions.FullBackupFrequency = types.StringValue("always")
@@ -622,7 +683,7 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	if schedules.fullBackup.command.Options.EncryptionKMSURI != nil && schedules.fullBackup.command.Options.EncryptionKMSURI[0] != nil {
-		data.BackupOptions.Kms = types.StringValue(schedules.fullBackup.command.Options.EncryptionKMSURI[0].String())
+		data.BackupOptions.Kms = types.StringValue(strings.Trim(schedules.fullBackup.command.Options.EncryptionKMSURI[0].String(), "'"))
 	}
 
 	if (schedules.fullBackup.command.Options.EncryptionPassphrase != nil && data.BackupOptions.EncryptionPassphrase.IsNull()) ||
@@ -638,7 +699,7 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 	if schedules.incrementalBackup != nil {
 		data.IncrementalBackupScheduleId = types.Int64Value(schedules.incrementalBackup.id)
 		if schedules.incrementalBackup.command.Options.IncrementalStorage != nil && schedules.incrementalBackup.command.Options.IncrementalStorage[0] != nil {
-			data.BackupOptions.IncrementalBackupLocation = types.StringValue(schedules.incrementalBackup.command.Options.IncrementalStorage[0].String())
+			data.BackupOptions.IncrementalBackupLocation = types.StringValue(strings.Trim(schedules.incrementalBackup.command.Options.IncrementalStorage[0].String(), "'"))
 		} else {
 			data.BackupOptions.IncrementalBackupLocation = types.StringNull()
 		}
@@ -646,6 +707,33 @@ func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequ
 		data.IncrementalBackupScheduleId = types.Int64Null()
 		data.BackupOptions.IncrementalBackupLocation = types.StringNull()
 	}
+}
+
+func (r *BackupScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackupScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	schedules, err := r.getBackupSchedules(ctx, data.ClusterId.ValueString(), data.Label.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read backup schedule", err.Error())
+		return
+	}
+
+	if schedules.fullBackup == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.applyBackupSchedules(schedules)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -661,6 +749,9 @@ func (r *BackupScheduleResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
 	header := fmt.Sprintf("ALTER BACKUP SCHEDULE %d", state.FullBackupScheduleId.ValueInt64())
 
 	updateSet := []string{}
@@ -732,7 +823,7 @@ func (r *BackupScheduleResource) Update(ctx context.Context, req resource.Update
 
 	scheduleIds, err := ccloud.SqlConWithTempUser(ctx, r.client, plan.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*scheduleIdSet, error) {
 		schedules := scheduleIdSet{}
-		rows, err := db.Query(fullQuery)
+		rows, err := db.QueryEx(ctx, fullQuery, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -773,6 +864,13 @@ func (r *BackupScheduleResource) Update(ctx context.Context, req resource.Update
 	}
 	plan.Id = types.StringValue(getBackupScheduleId(plan.ClusterId.ValueString(), plan.Label.ValueString()))
 
+	nextRun, err := r.setBackupSchedulePaused(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to set backup schedule paused state", err.Error())
+		return
+	}
+	plan.NextRun = *nextRun
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -785,8 +883,11 @@ func (r *BackupScheduleResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
-		_, err := db.Exec("drop schedules with x as (show schedules for backup) select id from x where label = $1", data.Label.ValueString())
+		_, err := db.ExecEx(ctx, "drop schedules with x as (show schedules for backup) select id from x where label = $1", nil, data.Label.ValueString())
 		if err != nil {
 			return nil, err
 		}
@@ -800,3 +901,55 @@ func (r *BackupScheduleResource) Delete(ctx context.Context, req resource.Delete
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func (r *BackupScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 3 || idParts[0] != "backup_schedule" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format backup_schedule|<cluster_id>|<label>, got: %s", req.ID))
+		return
+	}
+
+	schedules, err := r.getBackupSchedules(ctx, idParts[1], idParts[2])
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read backup schedule", err.Error())
+		return
+	}
+
+	if schedules.fullBackup == nil {
+		resp.Diagnostics.AddError("Unable to import backup schedule", fmt.Sprintf("Backup schedule %s not found", idParts[2]))
+		return
+	}
+
+	data := BackupScheduleResourceModel{
+		ClusterId: types.StringValue(idParts[1]),
+		Label:     types.StringValue(idParts[2]),
+		Target: &struct {
+			Tables            types.List `tfsdk:"tables"`
+			Databases         types.List `tfsdk:"databases"`
+			FullClusterBackup types.Bool `tfsdk:"full_cluster_backup"`
+		}{
+			Tables:    types.ListNull(types.StringType),
+			Databases: types.ListNull(types.StringType),
+		},
+		ScheduleOptions: &struct {
+			FirstRun              types.String `tfsdk:"first_run"`
+			OnExecutionFailure    types.String `tfsdk:"on_execution_failure"`
+			OnPreviousRunning     types.String `tfsdk:"on_previous_running"`
+			IgnoreExistingBackups types.Bool   `tfsdk:"ignore_existing_backups"`
+		}{},
+		BackupOptions: &struct {
+			Kms                       types.String `tfsdk:"kms"`
+			EncryptionPassphrase      types.String `tfsdk:"encryption_passphrase"`
+			RevisionHistory           types.Bool   `tfsdk:"revision_history"`
+			FullBackupFrequency       types.String `tfsdk:"full_backup_frequency"`
+			IncrementalBackupLocation types.String `tfsdk:"incremental_backup_location"`
+		}{
+			// revision_history is only present in the command when enabled
+			RevisionHistory: types.BoolValue(false),
+		},
+		Timeouts: nullTimeouts(),
+	}
+	data.applyBackupSchedules(schedules)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,352 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProvider serves a single resource, so plans go through the same defaults and plan modifiers as in Terraform.
+type testProvider struct {
+	newResource func() resource.Resource
+}
+
+func (p *testProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "test"
+}
+
+func (p *testProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p *testProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p *testProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{p.newResource}
+}
+
+func (p *testProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// objectWithNulls returns an object of objectType with the given attributes, every other attribute is null.
+func objectWithNulls(objectType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.(tftypes.Object).AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// planCreate plans the creation of a resource from config.
+func planCreate(t *testing.T, newResource func() resource.Resource, typeName string, config func(objectType tftypes.Object) map[string]tftypes.Value) tfsdk.Plan {
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	configValue, err := tfprotov6.NewDynamicValue(objectType, objectWithNulls(objectType, config(objectType)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	priorState, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := providerserver.NewProtocol6(&testProvider{newResource: newResource})()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &priorState,
+		ProposedNewState: &configValue,
+		Config:           &configValue,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned}
+}
+
+// assertConsistentWithPlan fails like Terraform does when the state after apply differs from a known planned value.
+func assertConsistentWithPlan(t *testing.T, plan tfsdk.Plan, state tfsdk.State) {
+	if !state.Raw.IsFullyKnown() {
+		t.Error("state has unknown values")
+	}
+	err := tftypes.Walk(plan.Raw, func(attributePath *tftypes.AttributePath, planned tftypes.Value) (bool, error) {
+		if !planned.IsKnown() {
+			return false, nil
+		}
+		if planned.Type().Is(tftypes.Object{}) || planned.Type().Is(tftypes.List{}) || planned.Type().Is(tftypes.Map{}) || planned.Type().Is(tftypes.Set{}) {
+			return !planned.IsNull(), nil
+		}
+		actual, _, err := tftypes.WalkAttributePath(state.Raw, attributePath)
+		if err != nil {
+			t.Errorf("%s: planned %s, missing from state", attributePath, planned)
+			return false, nil
+		}
+		if !planned.Equal(actual.(tftypes.Value)) {
+			t.Errorf("%s: planned %s, got %s", attributePath, planned, actual)
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createChangefeedFromPlan runs the steps of Create that do not need a cluster and returns the statement it would
+// run and the state it would save.
+func createChangefeedFromPlan(t *testing.T, plan tfsdk.Plan) (string, ChangefeedResourceModel, tfsdk.State) {
+	ctx := context.Background()
+
+	var data ChangefeedResourceModel
+	if diags := plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to read plan: %v", diags)
+	}
+	data.resolvePlannedValues(ctx)
+	query := buildCreateChangefeedQuery(ctx, &data)
+
+	data.JobId = types.Int64Value(1)
+	data.Id = types.StringValue(getChangefeedId(data.ClusterId.ValueString(), 1))
+	data.Status = types.StringValue("running")
+	data.applyChangefeedProgress(&changefeedJobProgress{})
+
+	state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to set state: %v", diags)
+	}
+	return query, data, state
+}
+
+func TestChangefeedCreateWithDefaults(t *testing.T) {
+	plan := planCreate(t, NewChangefeedResource, "test_changefeed", func(objectType tftypes.Object) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"cluster_id": tftypes.NewValue(tftypes.String, "cluster"),
+			"target":     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "db.public.orders")}),
+			"sink_uri":   tftypes.NewValue(tftypes.String, "kafka://broker:9092"),
+			"options":    objectWithNulls(objectType.AttributeTypes["options"], nil),
+		}
+	})
+
+	query, data, state := createChangefeedFromPlan(t, plan)
+
+	if expected := "CREATE CHANGEFEED FOR TABLE db.public.orders INTO 'kafka://broker:9092' "; query != expected {
+		t.Errorf("expected query %q, got %q", expected, query)
+	}
+	if data.DesiredStatus.ValueString() != "running" {
+		t.Errorf("expected desired_status running, got %s", data.DesiredStatus)
+	}
+	if data.RecreateOnFailure.IsNull() || data.RecreateOnFailure.ValueBool() {
+		t.Errorf("expected recreate_on_failure false, got %s", data.RecreateOnFailure)
+	}
+	if data.UpdateStrategy.ValueString() != "pause_alter_resume" {
+		t.Errorf("expected update_strategy pause_alter_resume, got %s", data.UpdateStrategy)
+	}
+	assertConsistentWithPlan(t, plan, state)
+}
+
+func TestChangefeedCreateQueryOptions(t *testing.T) {
+	plan := planCreate(t, NewChangefeedResource, "test_changefeed", func(objectType tftypes.Object) map[string]tftypes.Value {
+		optionsType := objectType.AttributeTypes["options"]
+		return map[string]tftypes.Value{
+			"cluster_id":     tftypes.NewValue(tftypes.String, "cluster"),
+			"target":         tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "db.public.orders")}),
+			"sink_uri":       tftypes.NewValue(tftypes.String, "kafka://broker:9092"),
+			"desired_status": tftypes.NewValue(tftypes.String, "paused"),
+			"options": objectWithNulls(optionsType, map[string]tftypes.Value{
+				"resolved": tftypes.NewValue(tftypes.String, "10s"),
+				"diff":     tftypes.NewValue(tftypes.Bool, true),
+			}),
+		}
+	})
+
+	query, data, state := createChangefeedFromPlan(t, plan)
+
+	if !strings.HasSuffix(query, "WITH diff, resolved='10s'") {
+		t.Errorf("expected the options in the query, got %q", query)
+	}
+	if data.DesiredStatus.ValueString() != "paused" {
+		t.Errorf("expected desired_status paused, got %s", data.DesiredStatus)
+	}
+	assertConsistentWithPlan(t, plan, state)
+}
+
+func TestChangefeedCreateWithSink(t *testing.T) {
+	plan := planCreate(t, NewChangefeedResource, "test_changefeed", func(objectType tftypes.Object) map[string]tftypes.Value {
+		sinkType := objectType.AttributeTypes["sink"].(tftypes.Object)
+		return map[string]tftypes.Value{
+			"cluster_id": tftypes.NewValue(tftypes.String, "cluster"),
+			"target":     tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "db.public.orders")}),
+			"sink": objectWithNulls(sinkType, map[string]tftypes.Value{
+				"type": tftypes.NewValue(tftypes.String, "kafka"),
+				"host": tftypes.NewValue(tftypes.String, "broker:9092"),
+				"params": tftypes.NewValue(sinkType.AttributeTypes["params"], map[string]tftypes.Value{
+					"topic_prefix": tftypes.NewValue(tftypes.String, "cdc_"),
+				}),
+			}),
+			"options": objectWithNulls(objectType.AttributeTypes["options"], nil),
+		}
+	})
+
+	query, data, state := createChangefeedFromPlan(t, plan)
+
+	if expected := "CREATE CHANGEFEED FOR TABLE db.public.orders INTO 'kafka://broker:9092?topic_prefix=cdc_' "; query != expected {
+		t.Errorf("expected query %q, got %q", expected, query)
+	}
+	if data.SinkUri.ValueString() != "kafka://broker:9092?topic_prefix=cdc_" {
+		t.Errorf("expected the rendered sink URI in state, got %s", data.SinkUri)
+	}
+	assertConsistentWithPlan(t, plan, state)
+}
+
+func TestChangefeedCreateWithSinkExternalConnection(t *testing.T) {
+	plan := planCreate(t, NewChangefeedResource, "test_changefeed", func(objectType tftypes.Object) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"cluster_id":               tftypes.NewValue(tftypes.String, "cluster"),
+			"target":                   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "db.public.orders")}),
+			"sink_external_connection": tftypes.NewValue(tftypes.String, buildExternalConnectionId("cluster", "orders_sink")),
+			"options":                  objectWithNulls(objectType.AttributeTypes["options"], nil),
+		}
+	})
+
+	query, data, state := createChangefeedFromPlan(t, plan)
+
+	if expected := "CREATE CHANGEFEED FOR TABLE db.public.orders INTO 'external://orders_sink' "; query != expected {
+		t.Errorf("expected query %q, got %q", expected, query)
+	}
+	if data.SinkUri.ValueString() != "external://orders_sink" {
+		t.Errorf("expected the external connection URI in state, got %s", data.SinkUri)
+	}
+	assertConsistentWithPlan(t, plan, state)
+}
+
+func TestChangefeedCreateWithSelect(t *testing.T) {
+	plan := planCreate(t, NewChangefeedResource, "test_changefeed", func(objectType tftypes.Object) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"cluster_id": tftypes.NewValue(tftypes.String, "cluster"),
+			"select":     tftypes.NewValue(tftypes.String, "SELECT id, status FROM db.public.orders WHERE status != 'draft'"),
+			"sink_uri":   tftypes.NewValue(tftypes.String, "kafka://broker:9092"),
+			"options":    objectWithNulls(objectType.AttributeTypes["options"], nil),
+		}
+	})
+
+	query, data, state := createChangefeedFromPlan(t, plan)
+
+	if expected := "CREATE CHANGEFEED INTO 'kafka://broker:9092'  AS SELECT id, status FROM db.public.orders WHERE status != 'draft'"; query != expected {
+		t.Errorf("expected query %q, got %q", expected, query)
+	}
+	if data.SelectTable.ValueString() != "db.public.orders" {
+		t.Errorf("expected select_table db.public.orders, got %s", data.SelectTable)
+	}
+	assertConsistentWithPlan(t, plan, state)
+}
+
+// planUpdate plans changing the resource in prior to config and returns the paths that require replacement.
+func planUpdate(t *testing.T, newResource func() resource.Resource, typeName string, prior tfsdk.State, config func(objectType tftypes.Object) map[string]tftypes.Value) []*tftypes.AttributePath {
+	ctx := context.Background()
+
+	objectType := prior.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configAttributes := config(objectType)
+	configValue, err := tfprotov6.NewDynamicValue(objectType, objectWithNulls(objectType, configAttributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Like Terraform, computed attributes missing from the configuration keep their prior value
+	priorAttributes := map[string]tftypes.Value{}
+	if err := prior.Raw.As(&priorAttributes); err != nil {
+		t.Fatal(err)
+	}
+	proposedAttributes := map[string]tftypes.Value{}
+	for name, value := range priorAttributes {
+		proposedAttributes[name] = value
+		if configured, ok := configAttributes[name]; ok {
+			proposedAttributes[name] = configured
+		} else if attribute, ok := prior.Schema.GetAttributes()[name]; ok && !attribute.IsComputed() {
+			proposedAttributes[name] = tftypes.NewValue(objectType.AttributeTypes[name], nil)
+		}
+	}
+	proposedValue, err := tfprotov6.NewDynamicValue(objectType, objectWithNulls(objectType, proposedAttributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	priorValue, err := tfprotov6.NewDynamicValue(objectType, prior.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := providerserver.NewProtocol6(&testProvider{newResource: newResource})()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &priorValue,
+		ProposedNewState: &proposedValue,
+		Config:           &configValue,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	return resp.RequiresReplace
+}
+
+func TestChangefeedRecreateFromCursorAvoidsReplacement(t *testing.T) {
+	for _, updateStrategy := range []string{"pause_alter_resume", "recreate_from_cursor"} {
+		t.Run(updateStrategy, func(t *testing.T) {
+			config := func(options map[string]tftypes.Value) func(objectType tftypes.Object) map[string]tftypes.Value {
+				return func(objectType tftypes.Object) map[string]tftypes.Value {
+					return map[string]tftypes.Value{
+						"cluster_id":      tftypes.NewValue(tftypes.String, "cluster"),
+						"target":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "db.public.orders")}),
+						"sink_uri":        tftypes.NewValue(tftypes.String, "kafka://broker:9092"),
+						"update_strategy": tftypes.NewValue(tftypes.String, updateStrategy),
+						"options":         objectWithNulls(objectType.AttributeTypes["options"], options),
+					}
+				}
+			}
+
+			plan := planCreate(t, NewChangefeedResource, "test_changefeed", config(nil))
+			_, _, state := createChangefeedFromPlan(t, plan)
+
+			requiresReplace := planUpdate(t, NewChangefeedResource, "test_changefeed", state, config(map[string]tftypes.Value{
+				"end_time":        tftypes.NewValue(tftypes.String, "1700000000000000000.0000000000"),
+				"full_table_name": tftypes.NewValue(tftypes.Bool, true),
+				"initial_scan":    tftypes.NewValue(tftypes.String, "no"),
+			}))
+
+			expected := 3
+			if updateStrategy == "recreate_from_cursor" {
+				expected = 0
+			}
+			if len(requiresReplace) != expected {
+				t.Errorf("expected %d attributes to require replacement, got %v", expected, requiresReplace)
+			}
+		})
+	}
+}
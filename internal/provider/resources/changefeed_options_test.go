@@ -0,0 +1,104 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestChangefeedOptionEquivalent(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		a        attr.Value
+		b        attr.Value
+		expected bool
+	}{
+		{"flag unset and false", "diff", types.BoolNull(), types.BoolValue(false), true},
+		{"flag set and unset", "diff", types.BoolValue(true), types.BoolNull(), false},
+		{"string null", "format", types.StringNull(), types.StringNull(), true},
+		{"string set and null", "format", types.StringValue("json"), types.StringNull(), false},
+		{"string different", "format", types.StringValue("json"), types.StringValue("avro"), false},
+		{"duration go and interval", "resolved", types.StringValue("10s"), types.StringValue("00:00:10"), true},
+		{"duration minutes", "min_checkpoint_frequency", types.StringValue("1m"), types.StringValue("60s"), true},
+		{"duration different", "resolved", types.StringValue("10s"), types.StringValue("00:00:11"), false},
+		{"duration invalid", "resolved", types.StringValue("soon"), types.StringValue("10s"), false},
+		{"duration syntax on other option", "metrics_label", types.StringValue("1m"), types.StringValue("60s"), false},
+		{"json formatting", "kafka_sink_config", types.StringValue(`{"Flush": {"Messages": 10}}`), types.StringValue(`{"Flush":{"Messages":10}}`), true},
+		{"json key order", "webhook_sink_config", types.StringValue(`{"a": 1, "b": 2}`), types.StringValue(`{"b": 2, "a": 1}`), true},
+		{"json different", "kafka_sink_config", types.StringValue(`{"Flush": {"Messages": 10}}`), types.StringValue(`{"Flush": {"Messages": 20}}`), false},
+		{"json invalid", "kafka_sink_config", types.StringValue(`{`), types.StringValue(`{}`), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := changefeedOptionEquivalent(test.tag, test.a, test.b); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReconcileChangefeedOptions(t *testing.T) {
+	configured := ChangefeedOptionsModel{
+		Resolved:     types.StringValue("10s"),
+		Format:       types.StringValue("json"),
+		Diff:         types.BoolNull(),
+		Updated:      types.BoolValue(true),
+		MetricsLabel: types.StringValue("orders"),
+	}
+	current := ChangefeedOptionsModel{
+		Resolved:     types.StringValue("00:00:10"),
+		Format:       types.StringValue("avro"),
+		Diff:         types.BoolNull(),
+		Updated:      types.BoolValue(true),
+		MetricsLabel: types.StringNull(),
+		Envelope:     types.StringValue("wrapped"),
+		Cursor:       types.StringValue("1700000000000000000.0000000000"),
+	}
+
+	t.Run("all options", func(t *testing.T) {
+		options := configured
+		reconcileChangefeedOptions(&options, current, false)
+
+		expected := map[string][2]attr.Value{
+			"resolved keeps the configured form": {options.Resolved, types.StringValue("10s")},
+			"format is updated":                  {options.Format, types.StringValue("avro")},
+			"unset flag stays unset":             {options.Diff, types.BoolNull()},
+			"set flag is kept":                   {options.Updated, types.BoolValue(true)},
+			"removed option is unset":            {options.MetricsLabel, types.StringNull()},
+			"unmanaged option is read":           {options.Envelope, types.StringValue("wrapped")},
+			"cursor is read":                     {options.Cursor, current.Cursor},
+		}
+		for name, values := range expected {
+			if !values[0].Equal(values[1]) {
+				t.Errorf("%s: expected %s, got %s", name, values[1], values[0])
+			}
+		}
+	})
+
+	t.Run("managed options only", func(t *testing.T) {
+		options := configured
+		reconcileChangefeedOptions(&options, current, true)
+
+		if !options.Envelope.IsNull() {
+			t.Errorf("expected the unmanaged option to stay unset, got %s", options.Envelope)
+		}
+		if !options.Format.Equal(types.StringValue("avro")) {
+			t.Errorf("expected the managed option to be updated, got %s", options.Format)
+		}
+		if !options.Cursor.Equal(current.Cursor) {
+			t.Errorf("expected the cursor to be read, got %s", options.Cursor)
+		}
+	})
+
+	t.Run("flag removed from the changefeed", func(t *testing.T) {
+		options := ChangefeedOptionsModel{Diff: types.BoolValue(true)}
+		reconcileChangefeedOptions(&options, ChangefeedOptionsModel{}, true)
+
+		if !options.Diff.Equal(types.BoolValue(false)) {
+			t.Errorf("expected the flag to be false, got %s", options.Diff)
+		}
+	})
+}
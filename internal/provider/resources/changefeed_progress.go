@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+type ChangefeedWaitForModel struct {
+	InitialScanComplete types.Bool   `tfsdk:"initial_scan_complete"`
+	MaxLag              types.String `tfsdk:"max_lag"`
+	Timeout             types.String `tfsdk:"timeout"`
+}
+
+const defaultChangefeedWaitTimeout = 20 * time.Minute
+
+const changefeedWaitPollInterval = 5 * time.Second
+
+func changefeedWaitForAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Block create and update until the changefeed reaches this state. Ignored while `desired_status` is `paused`",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"initial_scan_complete": schema.BoolAttribute{
+				MarkdownDescription: "Wait until the initial scan is done and the changefeed has a high-water timestamp",
+				Optional:            true,
+			},
+			"max_lag": schema.StringAttribute{
+				MarkdownDescription: "Wait until the high-water timestamp is at most this far behind, like `30s`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(goDurationRegex, "Max lag must be a duration like 30s or 5m"),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait before failing the apply, defaults to `20m`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(goDurationRegex, "Timeout must be a duration like 30s or 20m"),
+				},
+			},
+		},
+	}
+}
+
+// changefeedJobProgress is the runtime state of a changefeed job.
+type changefeedJobProgress struct {
+	status             string
+	runningStatus      *string
+	error              *string
+	highWaterTimestamp *string
+	lagSeconds         *float64
+}
+
+// initialScanComplete reports whether the changefeed has a high-water timestamp, which is only set once the
+// initial scan is done.
+func (p *changefeedJobProgress) initialScanComplete() bool {
+	return p.highWaterTimestamp != nil && *p.highWaterTimestamp != "0"
+}
+
+const changefeedJobProgressColumns = `status, running_status, error, high_water_timestamp::STRING,
+	(extract(epoch FROM now()) - high_water_timestamp / 1e9)::FLOAT8`
+
+func getChangefeedJobProgress(ctx context.Context, db *pgx.ConnPool, jobId int64) (*changefeedJobProgress, error) {
+	progress := changefeedJobProgress{}
+	err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT %s FROM [SHOW CHANGEFEED JOB %d]", changefeedJobProgressColumns, jobId), nil).
+		Scan(&progress.status, &progress.runningStatus, &progress.error, &progress.highWaterTimestamp, &progress.lagSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// waitForJob polls the changefeed job until check accepts its progress or ctx is done.
+func waitForJob(ctx context.Context, db *pgx.ConnPool, jobId int64, check func(progress *changefeedJobProgress) error, opts ...retry.Option) error {
+	return retry.Do(
+		func() error {
+			progress, err := getChangefeedJobProgress(ctx, db, jobId)
+			if err != nil {
+				return err
+			}
+			return check(progress)
+		},
+		append([]retry.Option{retry.Context(ctx)}, opts...)...,
+	)
+}
+
+func waitForJobStatus(ctx context.Context, db *pgx.ConnPool, jobId int64, status string) error {
+	return waitForJob(ctx, db, jobId, func(progress *changefeedJobProgress) error {
+		if progress.status != status {
+			return fmt.Errorf("job status never reached %s current status: %s", status, progress.status)
+		}
+		return nil
+	},
+		retry.Attempts(20),
+		retry.Delay(time.Second*2),
+	)
+}
+
+// applyChangefeedProgress updates the computed runtime attributes.
+func (data *ChangefeedResourceModel) applyChangefeedProgress(progress *changefeedJobProgress) {
+	data.HighWaterTimestamp = types.StringPointerValue(progress.highWaterTimestamp)
+	data.LagSeconds = types.Float64PointerValue(progress.lagSeconds)
+	data.RunningStatus = types.StringPointerValue(progress.runningStatus)
+	data.Error = types.StringNull()
+	if progress.error != nil && *progress.error != "" {
+		data.Error = types.StringValue(*progress.error)
+	}
+}
+
+// waitForChangefeed blocks until the changefeed reaches the state described by wait_for, then refreshes the
+// computed runtime attributes.
+func (r *ChangefeedResource) waitForChangefeed(ctx context.Context, data *ChangefeedResourceModel) error {
+	waitFor := data.WaitFor
+	if waitFor == nil || data.DesiredStatus.ValueString() == "paused" {
+		waitFor = &ChangefeedWaitForModel{}
+	}
+
+	timeout := defaultChangefeedWaitTimeout
+	if !waitFor.Timeout.IsNull() {
+		timeout, _ = time.ParseDuration(waitFor.Timeout.ValueString())
+	}
+	var maxLag *time.Duration
+	if !waitFor.MaxLag.IsNull() {
+		lag, _ := time.ParseDuration(waitFor.MaxLag.ValueString())
+		maxLag = &lag
+	}
+
+	// Nothing is left unknown in state if waiting fails
+	data.applyChangefeedProgress(&changefeedJobProgress{})
+
+	check := func(progress *changefeedJobProgress) error {
+		if !IsJobStatusRunning(progress.status) {
+			// The job will not make progress anymore, stop waiting
+			return retry.Unrecoverable(fmt.Errorf("changefeed job is %s: %s", progress.status, types.StringPointerValue(progress.error).ValueString()))
+		}
+		if waitFor.InitialScanComplete.ValueBool() && !progress.initialScanComplete() {
+			return fmt.Errorf("initial scan is not complete, running status: %s", types.StringPointerValue(progress.runningStatus).ValueString())
+		}
+		if maxLag != nil && (progress.lagSeconds == nil || *progress.lagSeconds > maxLag.Seconds()) {
+			return fmt.Errorf("changefeed is lagging more than %s", maxLag)
+		}
+		data.applyChangefeedProgress(progress)
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if waitFor.InitialScanComplete.ValueBool() || maxLag != nil {
+		tflog.Info(ctx, fmt.Sprintf("Waiting up to %s for changefeed job %d", timeout, data.JobId.ValueInt64()))
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		return nil, waitForJob(waitCtx, db, data.JobId.ValueInt64(), check,
+			retry.Attempts(uint(timeout/changefeedWaitPollInterval)+1),
+			retry.Delay(changefeedWaitPollInterval),
+			retry.DelayType(retry.FixedDelay),
+			retry.LastErrorOnly(true),
+		)
+	})
+	return err
+}
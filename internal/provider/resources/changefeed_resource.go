@@ -2,29 +2,33 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"go/constant"
 	"reflect"
-	"regexp"
 	"slices"
+	"strconv"
 	"strings"
-	"time"
 
-	"github.com/avast/retry-go"
 	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
 	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackc/pgx"
@@ -34,7 +38,8 @@ import (
 
 var _ resource.ResourceWithConfigValidators = &ChangefeedResource{}
 
-//var _ resource.ResourceWithImportState = &ChangefeedResource{}
+var _ resource.ResourceWithImportState = &ChangefeedResource{}
+var _ resource.ResourceWithModifyPlan = &ChangefeedResource{}
 
 func NewChangefeedResource() resource.Resource {
 	return &ChangefeedResource{}
@@ -46,6 +51,8 @@ type ChangefeedResource struct {
 
 type avroConfluentValidator struct {
 	resource.ConfigValidator
+	// registryAttributes are other attributes that also set the schema registry
+	registryAttributes []path.Path
 }
 
 func (v *avroConfluentValidator) Description(ctx context.Context) string {
@@ -55,9 +62,19 @@ func (v *avroConfluentValidator) MarkdownDescription(ctx context.Context) string
 	return "Confluent schema validator must be set when format is set to avro"
 }
 func (v *avroConfluentValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data ChangefeedResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	if data.Options.Format.ValueString() == "avro" && data.Options.ConfluentSchemaRegistry.IsNull() {
+	var options *ChangefeedOptionsModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options"), &options)...)
+	if options == nil {
+		return
+	}
+	for _, registryAttribute := range v.registryAttributes {
+		var registry types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, registryAttribute, &registry)...)
+		if !registry.IsNull() {
+			return
+		}
+	}
+	if options.Format.ValueString() == "avro" && options.ConfluentSchemaRegistry.IsNull() {
 		resp.Diagnostics.AddError("Confluent schema registry must be set when format is set to avro", "")
 	}
 }
@@ -75,64 +92,115 @@ func (v *keyColumnValidator) MarkdownDescription(ctx context.Context) string {
 }
 
 func (v *keyColumnValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data ChangefeedResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	if !data.Options.KeyColumn.IsNull() && !data.Options.Unordered.ValueBool() {
+	var options *ChangefeedOptionsModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("options"), &options)...)
+	if options == nil {
+		return
+	}
+	if !options.KeyColumn.IsNull() && !options.Unordered.ValueBool() {
 		resp.Diagnostics.AddError("Unordered must be true when key_column is set", "")
 	}
 }
 
+type sinkValidator struct {
+	resource.ConfigValidator
+}
+
+func (v *sinkValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v *sinkValidator) MarkdownDescription(ctx context.Context) string {
+	return "Sink must render into a valid sink URI"
+}
+
+func (v *sinkValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sink *ChangefeedSinkModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sink"), &sink)...)
+	if sink == nil || !sink.IsKnown() {
+		return
+	}
+	for key := range sink.Params.Elements() {
+		if _, ok := sink.SensitiveParams.Elements()[key]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("sink"), "Invalid sink", fmt.Sprintf("Query parameter %s is set in both params and sensitive_params", key))
+		}
+	}
+	if err := validateSinkUri(sink.Uri(ctx)); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("sink"), "Invalid sink", err.Error())
+	}
+}
+
 func (r *ChangefeedResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
-		&avroConfluentValidator{},
+		&avroConfluentValidator{registryAttributes: []path.Path{path.Root("schema_registry_external_connection")}},
 		&keyColumnValidator{},
+		&sinkValidator{},
 	}
 }
 
 type ChangefeedResourceModel struct {
-	ClusterId           types.String `tfsdk:"cluster_id"`
-	Id                  types.String `tfsdk:"id"`
-	JobId               types.Int64  `tfsdk:"job_id"`
-	Target              types.List   `tfsdk:"target"`
-	Select              types.String `tfsdk:"select"`
-	SinkUri             types.String `tfsdk:"sink_uri"`
-	InitialScanOnUpdate types.Bool   `tfsdk:"initial_scan_on_update"`
-	Status              types.String `tfsdk:"status"`
-	PersistentCursor    types.String `tfsdk:"persistent_cursor"`
-	Options             struct {
-		AvroSchemaPrefix             types.String `tfsdk:"avro_schema_prefix"`
-		Compression                  types.String `tfsdk:"compression"`
-		ConfluentSchemaRegistry      types.String `tfsdk:"confluent_schema_registry"`
-		Cursor                       types.String `tfsdk:"cursor"`
-		Diff                         types.Bool   `tfsdk:"diff"`
-		EndTime                      types.String `tfsdk:"end_time"`
-		Envelope                     types.String `tfsdk:"envelope"`
-		ExecutionLocality            types.String `tfsdk:"execution_locality"`
-		Format                       types.String `tfsdk:"format"`
-		FullTableName                types.Bool   `tfsdk:"full_table_name"`
-		GcProtectExpiresAfter        types.String `tfsdk:"gc_protect_expires_after"`
-		InitialScan                  types.String `tfsdk:"initial_scan"`
-		KafkaSinkConfig              types.String `tfsdk:"kafka_sink_config"`
-		KeyColumn                    types.String `tfsdk:"key_column"`
-		KeyInValue                   types.Bool   `tfsdk:"key_in_value"`
-		LaggingRangesThreshold       types.String `tfsdk:"lagging_ranges_threshold"`
-		LaggingRangesPollingInterval types.String `tfsdk:"lagging_ranges_polling_interval"`
-		MetricsLabel                 types.String `tfsdk:"metrics_label"`
-		MinCheckpointFrequency       types.String `tfsdk:"min_checkpoint_frequency"`
-		MvccTimestamp                types.Bool   `tfsdk:"mvcc_timestamp"`
-		OnError                      types.String `tfsdk:"on_error"`
-		ProtectDataFromGcOnPause     types.Bool   `tfsdk:"protect_data_from_gc_on_pause"`
-		Resolved                     types.String `tfsdk:"resolved"`
-		SchemaChangeEvents           types.String `tfsdk:"schema_change_events"`
-		SchemaChangePolicy           types.String `tfsdk:"schema_change_policy"`
-		SplitColumnFamilies          types.Bool   `tfsdk:"split_column_families"`
-		TopicInValue                 types.Bool   `tfsdk:"topic_in_value"`
-		Unordered                    types.Bool   `tfsdk:"unordered"`
-		Updated                      types.Bool   `tfsdk:"updated"`
-		VirtualColumns               types.String `tfsdk:"virtual_columns"`
-		WebhookAuthHeader            types.String `tfsdk:"webhook_auth_header"`
-		WebhookSinkConfig            types.String `tfsdk:"webhook_sink_config"`
-	} `tfsdk:"options"`
+	ClusterId                        types.String            `tfsdk:"cluster_id"`
+	Id                               types.String            `tfsdk:"id"`
+	JobId                            types.Int64             `tfsdk:"job_id"`
+	Target                           types.List              `tfsdk:"target"`
+	TargetFamily                     types.List              `tfsdk:"target_family"`
+	Select                           types.String            `tfsdk:"select"`
+	SelectTable                      types.String            `tfsdk:"select_table"`
+	SinkUri                          types.String            `tfsdk:"sink_uri"`
+	Sink                             *ChangefeedSinkModel    `tfsdk:"sink"`
+	SinkExternalConnection           types.String            `tfsdk:"sink_external_connection"`
+	SchemaRegistryExternalConnection types.String            `tfsdk:"schema_registry_external_connection"`
+	InitialScanOnUpdate              types.Bool              `tfsdk:"initial_scan_on_update"`
+	UpdateStrategy                   types.String            `tfsdk:"update_strategy"`
+	DesiredStatus                    types.String            `tfsdk:"desired_status"`
+	RecreateOnFailure                types.Bool              `tfsdk:"recreate_on_failure"`
+	Status                           types.String            `tfsdk:"status"`
+	RunningStatus                    types.String            `tfsdk:"running_status"`
+	Error                            types.String            `tfsdk:"error"`
+	HighWaterTimestamp               types.String            `tfsdk:"high_water_timestamp"`
+	LagSeconds                       types.Float64           `tfsdk:"lag_seconds"`
+	WaitFor                          *ChangefeedWaitForModel `tfsdk:"wait_for"`
+	PersistentCursor                 types.String            `tfsdk:"persistent_cursor"`
+	Options                          ChangefeedOptionsModel  `tfsdk:"options"`
+	Timeouts                         timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// ChangefeedOptionsModel holds the WITH options shared by changefeeds and scheduled changefeeds.
+type ChangefeedOptionsModel struct {
+	AvroSchemaPrefix             types.String            `tfsdk:"avro_schema_prefix"`
+	Compression                  types.String            `tfsdk:"compression"`
+	ConfluentSchemaRegistry      types.String            `tfsdk:"confluent_schema_registry"`
+	Cursor                       types.String            `tfsdk:"cursor"`
+	Diff                         types.Bool              `tfsdk:"diff"`
+	EndTime                      types.String            `tfsdk:"end_time"`
+	Envelope                     types.String            `tfsdk:"envelope"`
+	ExecutionLocality            types.String            `tfsdk:"execution_locality"`
+	Format                       types.String            `tfsdk:"format"`
+	FullTableName                types.Bool              `tfsdk:"full_table_name"`
+	GcProtectExpiresAfter        types.String            `tfsdk:"gc_protect_expires_after"`
+	InitialScan                  types.String            `tfsdk:"initial_scan"`
+	KafkaSinkConfig              types.String            `tfsdk:"kafka_sink_config"`
+	KafkaSinkSettings            *KafkaSinkConfigModel   `tfsdk:"kafka_sink_settings"`
+	KeyColumn                    types.String            `tfsdk:"key_column"`
+	KeyInValue                   types.Bool              `tfsdk:"key_in_value"`
+	LaggingRangesThreshold       types.String            `tfsdk:"lagging_ranges_threshold"`
+	LaggingRangesPollingInterval types.String            `tfsdk:"lagging_ranges_polling_interval"`
+	MetricsLabel                 types.String            `tfsdk:"metrics_label"`
+	MinCheckpointFrequency       types.String            `tfsdk:"min_checkpoint_frequency"`
+	MvccTimestamp                types.Bool              `tfsdk:"mvcc_timestamp"`
+	OnError                      types.String            `tfsdk:"on_error"`
+	ProtectDataFromGcOnPause     types.Bool              `tfsdk:"protect_data_from_gc_on_pause"`
+	Resolved                     types.String            `tfsdk:"resolved"`
+	SchemaChangeEvents           types.String            `tfsdk:"schema_change_events"`
+	SchemaChangePolicy           types.String            `tfsdk:"schema_change_policy"`
+	SplitColumnFamilies          types.Bool              `tfsdk:"split_column_families"`
+	TopicInValue                 types.Bool              `tfsdk:"topic_in_value"`
+	Unordered                    types.Bool              `tfsdk:"unordered"`
+	Updated                      types.Bool              `tfsdk:"updated"`
+	VirtualColumns               types.String            `tfsdk:"virtual_columns"`
+	WebhookAuthHeader            types.String            `tfsdk:"webhook_auth_header"`
+	WebhookSinkConfig            types.String            `tfsdk:"webhook_sink_config"`
+	WebhookSinkSettings          *WebhookSinkConfigModel `tfsdk:"webhook_sink_settings"`
 }
 
 func (r *ChangefeedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -189,16 +257,36 @@ If set, the changefeed will use this cursor to resume from.
 				Required:            false,
 				Optional:            true,
 				Validators: []validator.List{
-					listvalidator.ExactlyOneOf(path.MatchRoot("select")),
+					listvalidator.ConflictsWith(path.MatchRoot("select")),
 					listvalidator.UniqueValues(),
 					listvalidator.ValueStringsAre(
-						stringvalidator.RegexMatches(
-							regexp.MustCompile(`^[a-zA-Z0-9_]+?\.[a-zA-Z0-9_]+?\.[a-zA-Z0-9_]+?$`),
-							"Table names must be fully qualified",
-						),
+						ChangefeedTableValidator(),
 					),
 				},
 			},
+			"target_family": schema.ListNestedAttribute{
+				MarkdownDescription: "List of column families that the changefeed will watch, rendered as `TABLE table FAMILY family`",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("select")),
+					listvalidator.UniqueValues(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"table": schema.StringAttribute{
+							MarkdownDescription: "Fully qualified name of the table",
+							Required:            true,
+							Validators: []validator.String{
+								ChangefeedTableValidator(),
+							},
+						},
+						"family": schema.StringAttribute{
+							MarkdownDescription: "Name of the column family",
+							Required:            true,
+						},
+					},
+				},
+			},
 			"select": schema.StringAttribute{
 				MarkdownDescription: `
 SQL query that the changefeed will use to filter the watched tables.
@@ -207,17 +295,57 @@ SQL query that the changefeed will use to filter the watched tables.
 				Required: false,
 				Optional: true,
 				Validators: []validator.String{
-					stringvalidator.ExactlyOneOf(path.MatchRoot("target")),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("target"), path.MatchRoot("target_family")),
+					ChangefeedSelectValidator(),
 				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					changefeedSelectRequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"select_table": schema.StringAttribute{
+				MarkdownDescription: "Table the `select` query reads from",
+				Computed:            true,
+			},
 			"sink_uri": schema.StringAttribute{
-				MarkdownDescription: "URI of the sink where the changefeed will send the changes",
-				Required:            true,
+				MarkdownDescription: "URI of the sink where the changefeed will send the changes. Computed from `sink` when it is set",
+				Optional:            true,
+				Computed:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("sink"), path.MatchRoot("sink_external_connection")),
+					SinkUriValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						var data ChangefeedResourceModel
+						resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+						resp.RequiresReplace = !data.Select.IsNull()
+					}, "", ""),
+				},
+			},
+			"sink_external_connection": schema.StringAttribute{
+				MarkdownDescription: "ID of the external connection the changefeed sends the changes to, so the sink secrets only live in the external connection",
+				Optional:            true,
+				Validators: []validator.String{
+					ExternalConnectionIdValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						var data ChangefeedResourceModel
+						resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+						resp.RequiresReplace = !data.Select.IsNull()
+					}, "", ""),
+				},
+			},
+			"schema_registry_external_connection": schema.StringAttribute{
+				MarkdownDescription: "ID of the external connection used as `confluent_schema_registry`",
+				Optional:            true,
+				Validators: []validator.String{
+					ExternalConnectionIdValidator(),
+					stringvalidator.ConflictsWith(path.MatchRoot("options").AtName("confluent_schema_registry")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
 						var data ChangefeedResourceModel
@@ -226,6 +354,45 @@ SQL query that the changefeed will use to filter the watched tables.
 					}, "", ""),
 				},
 			},
+			"sink": schema.SingleNestedAttribute{
+				MarkdownDescription: "Structured sink that is rendered into `sink_uri`, so plans show which part of the sink changed",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+						var data ChangefeedResourceModel
+						resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+						resp.RequiresReplace = !data.Select.IsNull()
+					}, "", ""),
+				},
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Sink type, used as the URI scheme",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(sinkSchemeNames()...),
+						},
+					},
+					"host": schema.StringAttribute{
+						MarkdownDescription: "Host of the sink, like the kafka broker, the bucket, the pub/sub project or the external connection name",
+						Required:            true,
+					},
+					"path": schema.StringAttribute{
+						MarkdownDescription: "Path of the sink",
+						Optional:            true,
+					},
+					"params": schema.MapAttribute{
+						MarkdownDescription: "Query parameters of the sink URI",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"sensitive_params": schema.MapAttribute{
+						MarkdownDescription: "Query parameters of the sink URI that hold secrets",
+						Optional:            true,
+						Sensitive:           true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Status of the changefeed job",
@@ -241,6 +408,23 @@ SQL query that the changefeed will use to filter the watched tables.
 					stringvalidator.OneOf("running", "paused", "canceling", "canceled", "failed", "succeeded", "cancel-requested"),
 				},
 			},
+			"running_status": schema.StringAttribute{
+				MarkdownDescription: "Progress reported by the changefeed job, like the initial scan",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Error reported by the changefeed job",
+				Computed:            true,
+			},
+			"high_water_timestamp": schema.StringAttribute{
+				MarkdownDescription: "Timestamp up to which every change has been emitted, unset until the initial scan is complete",
+				Computed:            true,
+			},
+			"lag_seconds": schema.Float64Attribute{
+				MarkdownDescription: "How far the high-water timestamp is behind the current time, in seconds",
+				Computed:            true,
+			},
+			"wait_for": changefeedWaitForAttribute(),
 			"options": schema.SingleNestedAttribute{
 				Optional: true,
 				Required: false,
@@ -257,214 +441,41 @@ SQL query that the changefeed will use to filter the watched tables.
 Options for the changefeed.
 Documentation for the options can be found [here](https://www.cockroachlabs.com/docs/stable/create-changefeed#options)
 `,
-				Attributes: map[string]schema.Attribute{
-					"avro_schema_prefix": schema.StringAttribute{
-						MarkdownDescription: "Avro schema prefix",
-						Required:            false,
-						Optional:            true,
-					},
-					"compression": schema.StringAttribute{
-						MarkdownDescription: "Compression",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("gzip", "zstd"),
-						},
-					},
-					"confluent_schema_registry": schema.StringAttribute{
-						MarkdownDescription: "Confluent schema registry address for avro",
-						Required:            false,
-						Optional:            true,
-					},
-					"cursor": schema.StringAttribute{
-						MarkdownDescription: "Cursor",
-						Required:            false,
-						Optional:            false,
-						Computed:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.UseStateForUnknown(),
-						},
-					},
-					"diff": schema.BoolAttribute{
-						MarkdownDescription: "Diff",
-						Required:            false,
-						Optional:            true,
-					},
-					"end_time": schema.StringAttribute{
-						MarkdownDescription: "End time",
-						Required:            false,
-						Optional:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"envelope": schema.StringAttribute{
-						MarkdownDescription: "Envelope",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("wrapped", "bare", "key_only", "row"),
-						},
-					},
-					"execution_locality": schema.StringAttribute{
-						MarkdownDescription: "Execution locality",
-						Required:            false,
-						Optional:            true,
-					},
-					"format": schema.StringAttribute{
-						MarkdownDescription: "Format",
-						Required:            false,
-						Optional:            true,
-
-						Validators: []validator.String{
-							stringvalidator.Any(
-								stringvalidator.OneOf("json", "csv", "parquet"),
-								stringvalidator.All(
-									stringvalidator.OneOf("avro"),
-									stringvalidator.AlsoRequires(path.MatchRoot("options").AtName("confluent_schema_registry")),
-								),
-							),
-						},
-					},
-					"full_table_name": schema.BoolAttribute{
-						MarkdownDescription: "Full table name",
-						Required:            false,
-						Optional:            true,
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
-					},
-					"gc_protect_expires_after": schema.StringAttribute{
-						MarkdownDescription: "GC protect expires after",
-						Required:            false,
-						Optional:            true,
-					},
-					"initial_scan": schema.StringAttribute{
-						MarkdownDescription: "Initial scan",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("yes", "no", "only"),
-						},
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplaceIfConfigured(),
-						},
-					},
-					"kafka_sink_config": schema.StringAttribute{
-						MarkdownDescription: "Kafka sink config",
-						Required:            false,
-						Optional:            true,
-					},
-					"key_column": schema.StringAttribute{
-						MarkdownDescription: "Key column",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.AlsoRequires(path.MatchRoot("options").AtName("unordered")),
-						},
-					},
-					"key_in_value": schema.BoolAttribute{
-						MarkdownDescription: "Key in value",
-						Required:            false,
-						Optional:            true,
-					},
-					"lagging_ranges_threshold": schema.StringAttribute{
-						MarkdownDescription: "Lagging ranges threshold",
-						Required:            false,
-						Optional:            true,
-					},
-					"lagging_ranges_polling_interval": schema.StringAttribute{
-						MarkdownDescription: "Lagging ranges polling interval",
-						Required:            false,
-						Optional:            true,
-					},
-					"metrics_label": schema.StringAttribute{
-						MarkdownDescription: "Metrics label",
-						Required:            false,
-						Optional:            true,
-					},
-					"min_checkpoint_frequency": schema.StringAttribute{
-						MarkdownDescription: "Min checkpoint frequency",
-						Required:            false,
-						Optional:            true,
-					},
-					"mvcc_timestamp": schema.BoolAttribute{
-						MarkdownDescription: "MVCC timestamp",
-						Required:            false,
-						Optional:            true,
-					},
-					"on_error": schema.StringAttribute{
-						MarkdownDescription: "On error",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("pause", "fail"),
-						},
-					},
-					"protect_data_from_gc_on_pause": schema.BoolAttribute{
-						MarkdownDescription: "Protect data from GC on pause",
-						Required:            false,
-						Optional:            true,
-					},
-					"resolved": schema.StringAttribute{
-						MarkdownDescription: "Resolved",
-						Required:            false,
-						Optional:            true,
-					},
-					"schema_change_events": schema.StringAttribute{
-						MarkdownDescription: "Schema change events",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("default", "column_changes"),
-						},
-					},
-					"schema_change_policy": schema.StringAttribute{
-						MarkdownDescription: "Schema change policy",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("backfill", "no_backfill", "stop"),
-						},
-					},
-					"split_column_families": schema.BoolAttribute{
-						MarkdownDescription: "Split column families",
-						Required:            false,
-						Optional:            true,
-					},
-					"topic_in_value": schema.BoolAttribute{
-						MarkdownDescription: "Topic in value",
-						Required:            false,
-						Optional:            true,
-					},
-					"unordered": schema.BoolAttribute{
-						MarkdownDescription: "Unordered",
-						Required:            false,
-						Optional:            true,
-					},
-					"updated": schema.BoolAttribute{
-						MarkdownDescription: "Updated",
-						Required:            false,
-						Optional:            true,
-					},
-					"virtual_columns": schema.StringAttribute{
-						MarkdownDescription: "Virtual columns",
-						Required:            false,
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("null", "omitted"),
-						},
-					},
-					"webhook_auth_header": schema.StringAttribute{
-						MarkdownDescription: "Webhook auth header",
-						Required:            false,
-						Optional:            true,
-					},
-					"webhook_sink_config": schema.StringAttribute{
-						MarkdownDescription: "Webhook sink config",
-						Required:            false,
-						Optional:            true,
-					},
+				Attributes: changefeedOptionsAttributes(),
+			},
+			"desired_status": schema.StringAttribute{
+				MarkdownDescription: "Whether the changefeed job should be `running` or `paused`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("running"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("running", "paused"),
+				},
+			},
+			"recreate_on_failure": schema.BoolAttribute{
+				MarkdownDescription: `
+Recreate the changefeed from its persistent cursor when the job failed or was canceled.
+When disabled, a job that is no longer running is removed from the state.
+`,
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("persistent_cursor")),
+				},
+			},
+			"update_strategy": schema.StringAttribute{
+				MarkdownDescription: `
+How changes to the targets, sink or options are applied.
+` + "`pause_alter_resume`" + ` pauses the job, alters it and resumes it.
+` + "`recreate_from_cursor`" + ` cancels the job and creates a new one starting from its high-water timestamp, which also allows changing ` + "`end_time`, `full_table_name` and `initial_scan`" + ` without replacing the resource. Changefeeds with a ` + "`select`" + ` query are always replaced.
+Changes that do not touch the job are always applied without pausing it.
+`,
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("pause_alter_resume"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("pause_alter_resume", "recreate_from_cursor"),
 				},
 			},
 			"initial_scan_on_update": schema.BoolAttribute{
@@ -476,6 +487,251 @@ Documentation for the options can be found [here](https://www.cockroachlabs.com/
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// changefeedOptionsAttributes returns the schema of the options block shared by changefeeds and scheduled changefeeds.
+const recreatedFromCursorDescription = "Requires replacement unless the changefeed is recreated from its cursor"
+
+// recreatedFromCursor reports whether changes to the planned changefeed are applied by recreating it from its
+// high-water timestamp, so options that cannot be altered do not require replacement.
+func recreatedFromCursor(ctx context.Context, plan tfsdk.Plan) bool {
+	var updateStrategy, selectQuery types.String
+	// Scheduled changefeeds share the options but have neither attribute
+	if plan.GetAttribute(ctx, path.Root("update_strategy"), &updateStrategy).HasError() ||
+		plan.GetAttribute(ctx, path.Root("select"), &selectQuery).HasError() {
+		return false
+	}
+	// Changefeeds with a select statement cannot be updated at all
+	return updateStrategy.ValueString() == "recreate_from_cursor" && selectQuery.IsNull()
+}
+
+func changefeedOptionsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"avro_schema_prefix": schema.StringAttribute{
+			MarkdownDescription: "Avro schema prefix",
+			Required:            false,
+			Optional:            true,
+		},
+		"compression": schema.StringAttribute{
+			MarkdownDescription: "Compression",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("gzip", "zstd"),
+			},
+		},
+		"confluent_schema_registry": schema.StringAttribute{
+			MarkdownDescription: "Confluent schema registry address for avro",
+			Required:            false,
+			Optional:            true,
+		},
+		"cursor": schema.StringAttribute{
+			MarkdownDescription: "Cursor",
+			Required:            false,
+			Optional:            false,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"diff": schema.BoolAttribute{
+			MarkdownDescription: "Diff",
+			Required:            false,
+			Optional:            true,
+		},
+		"end_time": schema.StringAttribute{
+			MarkdownDescription: "End time",
+			Required:            false,
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = !recreatedFromCursor(ctx, req.Plan)
+				}, recreatedFromCursorDescription, recreatedFromCursorDescription),
+			},
+		},
+		"envelope": schema.StringAttribute{
+			MarkdownDescription: "Envelope",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("wrapped", "bare", "key_only", "row"),
+			},
+		},
+		"execution_locality": schema.StringAttribute{
+			MarkdownDescription: "Execution locality",
+			Required:            false,
+			Optional:            true,
+		},
+		"format": schema.StringAttribute{
+			MarkdownDescription: "Format",
+			Required:            false,
+			Optional:            true,
+
+			Validators: []validator.String{
+				stringvalidator.Any(
+					stringvalidator.OneOf("json", "csv", "parquet"),
+					stringvalidator.All(
+						stringvalidator.OneOf("avro"),
+						stringvalidator.AlsoRequires(path.MatchRoot("options").AtName("confluent_schema_registry")),
+					),
+				),
+			},
+		},
+		"full_table_name": schema.BoolAttribute{
+			MarkdownDescription: "Full table name",
+			Required:            false,
+			Optional:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = !recreatedFromCursor(ctx, req.Plan)
+				}, recreatedFromCursorDescription, recreatedFromCursorDescription),
+			},
+		},
+		"gc_protect_expires_after": schema.StringAttribute{
+			MarkdownDescription: "GC protect expires after",
+			Required:            false,
+			Optional:            true,
+		},
+		"initial_scan": schema.StringAttribute{
+			MarkdownDescription: "Initial scan",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("yes", "no", "only"),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = !req.ConfigValue.IsNull() && !recreatedFromCursor(ctx, req.Plan)
+				}, recreatedFromCursorDescription, recreatedFromCursorDescription),
+			},
+		},
+		"kafka_sink_config": schema.StringAttribute{
+			MarkdownDescription: "Kafka sink config as JSON, see `kafka_sink_settings` for a typed alternative",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				JsonObjectValidator(),
+			},
+		},
+		"kafka_sink_settings": kafkaSinkConfigAttribute(),
+		"key_column": schema.StringAttribute{
+			MarkdownDescription: "Key column",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("options").AtName("unordered")),
+			},
+		},
+		"key_in_value": schema.BoolAttribute{
+			MarkdownDescription: "Key in value",
+			Required:            false,
+			Optional:            true,
+		},
+		"lagging_ranges_threshold": schema.StringAttribute{
+			MarkdownDescription: "Lagging ranges threshold",
+			Required:            false,
+			Optional:            true,
+		},
+		"lagging_ranges_polling_interval": schema.StringAttribute{
+			MarkdownDescription: "Lagging ranges polling interval",
+			Required:            false,
+			Optional:            true,
+		},
+		"metrics_label": schema.StringAttribute{
+			MarkdownDescription: "Metrics label",
+			Required:            false,
+			Optional:            true,
+		},
+		"min_checkpoint_frequency": schema.StringAttribute{
+			MarkdownDescription: "Min checkpoint frequency",
+			Required:            false,
+			Optional:            true,
+		},
+		"mvcc_timestamp": schema.BoolAttribute{
+			MarkdownDescription: "MVCC timestamp",
+			Required:            false,
+			Optional:            true,
+		},
+		"on_error": schema.StringAttribute{
+			MarkdownDescription: "On error",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("pause", "fail"),
+			},
+		},
+		"protect_data_from_gc_on_pause": schema.BoolAttribute{
+			MarkdownDescription: "Protect data from GC on pause",
+			Required:            false,
+			Optional:            true,
+		},
+		"resolved": schema.StringAttribute{
+			MarkdownDescription: "Resolved",
+			Required:            false,
+			Optional:            true,
+		},
+		"schema_change_events": schema.StringAttribute{
+			MarkdownDescription: "Schema change events",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("default", "column_changes"),
+			},
+		},
+		"schema_change_policy": schema.StringAttribute{
+			MarkdownDescription: "Schema change policy",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("backfill", "no_backfill", "stop"),
+			},
+		},
+		"split_column_families": schema.BoolAttribute{
+			MarkdownDescription: "Split column families",
+			Required:            false,
+			Optional:            true,
+		},
+		"topic_in_value": schema.BoolAttribute{
+			MarkdownDescription: "Topic in value",
+			Required:            false,
+			Optional:            true,
+		},
+		"unordered": schema.BoolAttribute{
+			MarkdownDescription: "Unordered",
+			Required:            false,
+			Optional:            true,
+		},
+		"updated": schema.BoolAttribute{
+			MarkdownDescription: "Updated",
+			Required:            false,
+			Optional:            true,
+		},
+		"virtual_columns": schema.StringAttribute{
+			MarkdownDescription: "Virtual columns",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("null", "omitted"),
+			},
+		},
+		"webhook_auth_header": schema.StringAttribute{
+			MarkdownDescription: "Webhook auth header",
+			Required:            false,
+			Optional:            true,
+		},
+		"webhook_sink_config": schema.StringAttribute{
+			MarkdownDescription: "Webhook sink config as JSON, see `webhook_sink_settings` for a typed alternative",
+			Required:            false,
+			Optional:            true,
+			Validators: []validator.String{
+				JsonObjectValidator(),
+			},
+		},
+		"webhook_sink_settings": webhookSinkConfigAttribute(),
 	}
 }
 
@@ -498,22 +754,30 @@ func (r *ChangefeedResource) Configure(ctx context.Context, req resource.Configu
 func (r *ChangefeedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ChangefeedResourceModel
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	// The plan holds the defaults and the values ModifyPlan derives from the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var cursorKey string
-	// Check if the persistent_cursor is set
+	data.resolvePlannedValues(ctx)
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	var cursor PersistentCursorRef
+	var rewindTo *string
+	// Check if the persistent_cursor is set
 	if !data.PersistentCursor.IsNull() {
-		_, cursorKey, err := ParseCursorId(data.PersistentCursor.ValueString())
+		var err error
+		cursor, err = ParseCursorId(data.PersistentCursor.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to parse persistent cursor ID", err.Error())
 			return
 		}
 
-		cursorValue, err := GetCursor(ctx, r.client, data.ClusterId.ValueString(), cursorKey)
+		cursorValue, err := GetCursor(ctx, r.client, cursor)
 
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to get persistent cursor", err.Error())
@@ -523,6 +787,7 @@ func (r *ChangefeedResource) Create(ctx context.Context, req resource.CreateRequ
 		if cursorValue.OffsetCursor != nil {
 			data.Options.Cursor = types.StringValue(*cursorValue.OffsetCursor)
 		}
+		rewindTo = cursorValue.RewindTo
 
 		if cursorValue.InUse {
 			resp.Diagnostics.AddError("Persistent cursor is in use", "The persistent cursor is currently in use by another job")
@@ -530,59 +795,20 @@ func (r *ChangefeedResource) Create(ctx context.Context, req resource.CreateRequ
 		}
 	}
 
-	// Iterate through the keys of the options struct and build a string of options ex: SET option1 = value1, option2 = value2
-	options := []string{}
-	optionsObjVal := reflect.ValueOf(data.Options)
-	for i := 0; i < optionsObjVal.NumField(); i++ {
-		value := optionsObjVal.Field(i).Interface()
-		// get tfsdk tag
-		tag := optionsObjVal.Type().Field(i).Tag.Get("tfsdk")
-
-		// Check if the value is a bool or string
-		switch v := value.(type) {
-		case types.Bool:
-			if !v.IsNull() {
-				options = append(options, tag)
-			}
-		case types.String:
-			if !v.IsNull() {
-				// If the value is a string, sanitize it and add it to the options string
-				options = append(options, fmt.Sprintf("%s=%s", tag, pq.QuoteLiteral(v.ValueString())))
-			}
-		}
-	}
-	optionsString := ""
-	if len(options) > 0 {
-		optionsString = fmt.Sprintf("WITH %s", strings.Join(options, ", "))
-	}
-
-	query := ""
-
-	if !data.Target.IsNull() {
-		targetStringList := make([]string, len(data.Target.Elements()))
-		data.Target.ElementsAs(ctx, &targetStringList, false)
-		targetString := strings.Join(targetStringList, ", ")
-
-		query = fmt.Sprintf("CREATE CHANGEFEED FOR %s INTO '%s' %s", targetString, data.SinkUri.ValueString(), optionsString)
-	}
-
-	if !data.Select.IsNull() {
-		query = fmt.Sprintf("CREATE CHANGEFEED INTO '%s' %s AS %s", data.SinkUri.ValueString(), optionsString, data.Select.ValueString())
-
-	}
+	query := buildCreateChangefeedQuery(ctx, &data)
 
 	tflog.Info(ctx, fmt.Sprintf("Creating changefeed with query: %s", query))
 
 	jobId, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*int64, error) {
 		var jobId int64
-		err := db.QueryRow(query).Scan(&jobId)
+		err := db.QueryRowEx(ctx, query, nil).Scan(&jobId)
 
 		if err != nil {
 			return nil, err
 		}
 
 		// wait for job to be running
-		err = waitForJobStatus(db, jobId, "running")
+		err = waitForJobStatus(ctx, db, jobId, "running")
 		return &jobId, err
 	})
 
@@ -601,17 +827,17 @@ func (r *ChangefeedResource) Create(ctx context.Context, req resource.CreateRequ
 	data.Status = types.StringValue("running")
 
 	if !data.PersistentCursor.IsNull() {
-		err = UpdateCursorJobId(ctx, r.client, data.ClusterId.ValueString(), cursorKey, jobId)
+		err = UpdateCursorJobId(ctx, r.client, cursor, jobId)
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to update cursor job ID", err.Error())
 			_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-				_, err := db.Exec(fmt.Sprintf("CANCEL JOB %d", data.JobId.ValueInt64()))
+				_, err := db.ExecEx(ctx, fmt.Sprintf("CANCEL JOB %d", data.JobId.ValueInt64()), nil)
 
 				if err != nil {
 					return nil, err
 				}
 
-				err = waitForJobStatus(db, data.JobId.ValueInt64(), "canceled")
+				err = waitForJobStatus(ctx, db, data.JobId.ValueInt64(), "canceled")
 
 				return nil, err
 			})
@@ -620,40 +846,128 @@ func (r *ChangefeedResource) Create(ctx context.Context, req resource.CreateRequ
 			}
 			return
 		}
+
+		if rewindTo != nil {
+			if err := ClearCursorRewind(ctx, r.client, cursor, *rewindTo); err != nil {
+				resp.Diagnostics.AddWarning("Unable to clear persistent cursor rewind", fmt.Sprintf("The next changefeed created from the cursor will rewind again: %s", err.Error()))
+			}
+		}
+	}
+
+	if data.DesiredStatus.ValueString() == "paused" {
+		if err := r.setChangefeedJobStatus(ctx, &data, "paused"); err != nil {
+			resp.Diagnostics.AddError("Unable to pause changefeed job", err.Error())
+			return
+		}
+	}
+
+	if err := r.waitForChangefeed(ctx, &data); err != nil {
+		// The changefeed exists, keep it in state so it is replaced instead of leaked
+		resp.Diagnostics.AddError("Changefeed did not reach the wait_for state", err.Error())
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func removeQuotes(s string) string {
-	return strings.Trim(strings.Trim(s, "\""), "'")
+// resolvePlannedValues fills the computed values that are still unknown in the plan of a new changefeed.
+func (data *ChangefeedResourceModel) resolvePlannedValues(ctx context.Context) {
+	// The structured sink is what the changefeed is created with, sink_uri only mirrors it
+	if data.Sink != nil {
+		data.SinkUri = types.StringValue(data.Sink.Uri(ctx))
+	}
+	if !data.SinkExternalConnection.IsNull() {
+		data.SinkUri = externalConnectionRefUri(data.SinkExternalConnection)
+	}
+
+	data.SelectTable = types.StringNull()
+	if !data.Select.IsNull() {
+		if _, table, err := parseChangefeedSelect(data.Select.ValueString()); err == nil {
+			data.SelectTable = types.StringValue(table)
+		}
+	}
+	// The cursor is only known when the changefeed is created from a persistent cursor
+	if data.Options.Cursor.IsUnknown() {
+		data.Options.Cursor = types.StringNull()
+	}
 }
 
-func (r *ChangefeedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data ChangefeedResourceModel
+// buildCreateChangefeedQuery renders the CREATE CHANGEFEED statement for the changefeed.
+func buildCreateChangefeedQuery(ctx context.Context, data *ChangefeedResourceModel) string {
+	optionsString := buildChangefeedOptions(data.effectiveOptions())
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	query := ""
 
-	if resp.Diagnostics.HasError() {
-		return
+	if !data.Target.IsNull() || !data.TargetFamily.IsNull() {
+		targets, _ := data.changefeedTargets(ctx)
+
+		query = fmt.Sprintf("CREATE CHANGEFEED FOR %s INTO '%s' %s", tree.AsString(&targets), data.SinkUri.ValueString(), optionsString)
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Reading changefeed with job ID: %d", data.JobId.ValueInt64()))
+	if !data.Select.IsNull() {
+		query = fmt.Sprintf("CREATE CHANGEFEED INTO '%s' %s AS %s", data.SinkUri.ValueString(), optionsString, data.Select.ValueString())
+
+	}
+
+	return query
+}
+
+// buildChangefeedOptions renders every set option as a WITH clause, or an empty string when no option is set.
+func buildChangefeedOptions(changefeedOptions ChangefeedOptionsModel) string {
+	// Iterate through the keys of the options struct and build a string of options ex: SET option1 = value1, option2 = value2
+	options := []string{}
+	optionsObjVal := reflect.ValueOf(changefeedOptions.withRenderedSinkConfigs())
+	for i := 0; i < optionsObjVal.NumField(); i++ {
+		value := optionsObjVal.Field(i).Interface()
+		// get tfsdk tag
+		tag := optionsObjVal.Type().Field(i).Tag.Get("tfsdk")
+
+		// Check if the value is a bool or string
+		switch v := value.(type) {
+		case types.Bool:
+			if v.ValueBool() {
+				options = append(options, tag)
+			}
+		case types.String:
+			if !v.IsNull() {
+				// If the value is a string, sanitize it and add it to the options string
+				options = append(options, fmt.Sprintf("%s=%s", tag, pq.QuoteLiteral(v.ValueString())))
+			}
+		}
+	}
+	optionsString := ""
+	if len(options) > 0 {
+		optionsString = fmt.Sprintf("WITH %s", strings.Join(options, ", "))
+	}
+
+	return optionsString
+}
+
+func removeQuotes(s string) string {
+	return strings.Trim(strings.Trim(s, "\""), "'")
+}
+
+type changefeedJobInfo struct {
+	uri            string
+	statement      *tree.CreateChangefeed
+	status         string
+	fullTableNames []string
+	progress       changefeedJobProgress
+}
 
-	changefeedInfo, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*struct {
+// getChangefeedJob reads the changefeed job and parses the statement it was created with.
+func (r *ChangefeedResource) getChangefeedJob(ctx context.Context, clusterId string, jobId int64) (*changefeedJobInfo, error) {
+	changefeedInfo, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*struct {
 		uri            string
 		statement      string
-		status         string
 		fullTableNames []string
-		highWaterMark  *float64
+		progress       changefeedJobProgress
 	}, error) {
 		var statement string
-		var status string
 		var uri string
 		var fullTableNames []string
-		var highWaterMark *float64
-		err := db.QueryRow(fmt.Sprintf("SELECT description, status, sink_uri, full_table_names, high_water_timestamp from [SHOW CHANGEFEED JOB %d]", data.JobId.ValueInt64())).
-			Scan(&statement, &status, &uri, &fullTableNames, &highWaterMark)
+		progress := changefeedJobProgress{}
+		err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT description, sink_uri, full_table_names, %s from [SHOW CHANGEFEED JOB %d]", changefeedJobProgressColumns, jobId), nil).
+			Scan(&statement, &uri, &fullTableNames, &progress.status, &progress.runningStatus, &progress.error, &progress.highWaterTimestamp, &progress.lagSeconds)
 		if err != nil {
 			return nil, err
 		}
@@ -661,140 +975,328 @@ func (r *ChangefeedResource) Read(ctx context.Context, req resource.ReadRequest,
 		result := struct {
 			uri            string
 			statement      string
-			status         string
 			fullTableNames []string
-			highWaterMark  *float64
+			progress       changefeedJobProgress
 		}{
 			uri:            uri,
 			statement:      statement,
-			status:         status,
 			fullTableNames: fullTableNames,
-			highWaterMark:  highWaterMark,
+			progress:       progress,
 		}
 		return &result, nil
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to read changefeed job", err.Error())
-		return
-	}
-
-	if !IsJobStatusRunning(changefeedInfo.status) {
-		//resp.Diagnostics.AddError("Changefeed job in unexpected state", fmt.Sprintf("Changefeed job is in state: %s", changefeedInfo.status))
-		resp.State.RemoveResource(ctx)
-		return
+		return nil, err
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Changefeed statement: %s", changefeedInfo.statement))
 
 	parsedChangefeedStatementUnchecked, err := parser.ParseOne(changefeedInfo.statement)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to parse changefeed statement", err.Error())
-		return
+		return nil, err
 	}
 	parsedChangefeedStatement, ok := parsedChangefeedStatementUnchecked.AST.(*tree.CreateChangefeed)
+	if !ok || (parsedChangefeedStatement.Targets == nil && parsedChangefeedStatement.Select == nil) {
+		return nil, fmt.Errorf("unable to parse changefeed statement")
+	}
+
+	return &changefeedJobInfo{
+		uri:            changefeedInfo.uri,
+		statement:      parsedChangefeedStatement,
+		status:         changefeedInfo.progress.status,
+		fullTableNames: changefeedInfo.fullTableNames,
+		progress:       changefeedInfo.progress,
+	}, nil
+}
+
+// externalConnectionIdFromUri returns the ID of the external connection resource an external:// URI refers to,
+// or null for other URIs.
+func (data *ChangefeedResourceModel) externalConnectionIdFromUri(uri string) types.String {
+	connectionName, ok := externalConnectionNameFromUri(uri)
 	if !ok {
-		resp.Diagnostics.AddError("Unable to parse changefeed statement", "Unable to parse changefeed statement")
-		return
+		return types.StringNull()
 	}
+	return types.StringValue(buildExternalConnectionId(data.ClusterId.ValueString(), connectionName))
+}
+
+// effectiveOptions returns the options as they are sent to CockroachDB.
+func (data *ChangefeedResourceModel) effectiveOptions() ChangefeedOptionsModel {
+	options := data.Options.withRenderedSinkConfigs()
+	if !data.SchemaRegistryExternalConnection.IsNull() {
+		options.ConfluentSchemaRegistry = externalConnectionRefUri(data.SchemaRegistryExternalConnection)
+	}
+	return options
+}
 
+// applyChangefeedJob updates data from the changefeed job.
+func (data *ChangefeedResourceModel) applyChangefeedJob(ctx context.Context, changefeedInfo *changefeedJobInfo) {
+	parsedChangefeedStatement := changefeedInfo.statement
 	if parsedChangefeedStatement.Targets != nil {
-		targets := make([]attr.Value, len(parsedChangefeedStatement.Targets))
-		for i, target := range parsedChangefeedStatement.Targets {
-			targets[i] = types.StringValue(target.TableName.String())
-		}
-		data.Target, _ = types.ListValue(types.StringType, targets)
-	} else if parsedChangefeedStatement.Select != nil {
-		data.Select = types.StringValue(parsedChangefeedStatement.Select.String())
+		data.applyChangefeedTargets(ctx, parsedChangefeedStatement.Targets)
+		data.SelectTable = types.StringNull()
 	} else {
-		resp.Diagnostics.AddError("Unable to parse changefeed statement", "Unable to parse changefeed statement")
-		return
+		// Keep the configured formatting as long as it parses to the same query
+		currentSelect := parsedChangefeedStatement.Select.String()
+		if data.Select.IsNull() || !changefeedSelectEquivalent(data.Select.ValueString(), currentSelect) {
+			data.Select = types.StringValue(currentSelect)
+		}
+		_, table, err := parseChangefeedSelect(currentSelect)
+		if err == nil {
+			data.SelectTable = types.StringValue(table)
+		} else {
+			data.SelectTable = types.StringNull()
+		}
 	}
 
 	if !CompareURLs(data.SinkUri.ValueString(), changefeedInfo.uri) {
 		data.SinkUri = types.StringValue(changefeedInfo.uri)
 	}
+	if !data.SinkExternalConnection.IsNull() {
+		data.SinkExternalConnection = data.externalConnectionIdFromUri(data.SinkUri.ValueString())
+	}
+
+	// Parse the options, the schema registry external connection is compared in its external:// form
+	current := ChangefeedOptionsModel{}
+	current.applyKVOptions(parsedChangefeedStatement.Options)
+	registryConnection := !data.SchemaRegistryExternalConnection.IsNull()
+	if registryConnection {
+		data.Options.ConfluentSchemaRegistry = externalConnectionRefUri(data.SchemaRegistryExternalConnection)
+	}
+	reconcileChangefeedOptions(&data.Options, current, false)
+	if registryConnection {
+		data.SchemaRegistryExternalConnection = data.externalConnectionIdFromUri(data.Options.ConfluentSchemaRegistry.ValueString())
+		if !data.SchemaRegistryExternalConnection.IsNull() {
+			data.Options.ConfluentSchemaRegistry = types.StringNull()
+		}
+	}
+
+	data.Status = types.StringValue(changefeedInfo.status)
+	data.applyChangefeedProgress(&changefeedInfo.progress)
+	if isJobStatusPaused(changefeedInfo.status) {
+		data.DesiredStatus = types.StringValue("paused")
+	} else if changefeedInfo.status == "running" {
+		data.DesiredStatus = types.StringValue("running")
+	}
+}
+
+func (r *ChangefeedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChangefeedResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Info(ctx, fmt.Sprintf("Reading changefeed with job ID: %d", data.JobId.ValueInt64()))
+
+	changefeedInfo, err := r.getChangefeedJob(ctx, data.ClusterId.ValueString(), data.JobId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read changefeed job", err.Error())
+		return
+	}
 
-	// Parse the options
-	for _, option := range parsedChangefeedStatement.Options {
+	if !IsJobStatusRunning(changefeedInfo.status) {
+		if data.RecreateOnFailure.ValueBool() {
+			// Keep the job so ModifyPlan replaces it from the persistent cursor
+			resp.Diagnostics.AddWarning("Changefeed job is not running", fmt.Sprintf("Changefeed job %d is %s, it will be recreated from its persistent cursor", data.JobId.ValueInt64(), changefeedInfo.status))
+			data.Status = types.StringValue(changefeedInfo.status)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		//resp.Diagnostics.AddError("Changefeed job in unexpected state", fmt.Sprintf("Changefeed job is in state: %s", changefeedInfo.status))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if sinkUriMatches(ctx, r.client, data.ClusterId.ValueString(), data.SinkUri.ValueString(), changefeedInfo.uri) {
+		changefeedInfo.uri = data.SinkUri.ValueString()
+	}
+
+	data.applyChangefeedJob(ctx, changefeedInfo)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applyKVOptions sets the options found in a parsed changefeed statement.
+func (options *ChangefeedOptionsModel) applyKVOptions(kvOptions tree.KVOptions) {
+	for _, option := range kvOptions {
 		key := option.Key.String()
 
 		var value string
-		if option.Value != nil {
+		if strVal, ok := option.Value.(*tree.StrVal); ok {
+			value = strVal.RawString()
+		} else if option.Value != nil {
 			value = option.Value.String()
 			value = removeQuotes(value)
 		}
 
 		switch key {
 		case "avro_schema_prefix":
-			data.Options.AvroSchemaPrefix = types.StringValue(value)
+			options.AvroSchemaPrefix = types.StringValue(value)
 		case "compression":
-			data.Options.Compression = types.StringValue(value)
+			options.Compression = types.StringValue(value)
 		case "confluent_schema_registry":
-			data.Options.ConfluentSchemaRegistry = types.StringValue(value)
+			options.ConfluentSchemaRegistry = types.StringValue(value)
 		case "cursor":
-			data.Options.Cursor = types.StringValue(value)
+			options.Cursor = types.StringValue(value)
 		case "diff":
-			data.Options.Diff = types.BoolValue(true)
+			options.Diff = types.BoolValue(true)
 		case "end_time":
-			data.Options.EndTime = types.StringValue(value)
+			options.EndTime = types.StringValue(value)
 		case "envelope":
-			data.Options.Envelope = types.StringValue(value)
+			options.Envelope = types.StringValue(value)
 		case "execution_locality":
-			data.Options.ExecutionLocality = types.StringValue(value)
+			options.ExecutionLocality = types.StringValue(value)
 		case "format":
-			data.Options.Format = types.StringValue(value)
+			options.Format = types.StringValue(value)
 		case "full_table_name":
-			data.Options.FullTableName = types.BoolValue(true)
+			options.FullTableName = types.BoolValue(true)
 		case "gc_protect_expires_after":
-			data.Options.GcProtectExpiresAfter = types.StringValue(value)
+			options.GcProtectExpiresAfter = types.StringValue(value)
 		case "initial_scan":
-			data.Options.InitialScan = types.StringValue(value)
+			options.InitialScan = types.StringValue(value)
 		case "kafka_sink_config":
-			data.Options.KafkaSinkConfig = types.StringValue(value)
+			options.KafkaSinkConfig = types.StringValue(value)
 		case "key_column":
-			data.Options.KeyColumn = types.StringValue(value)
+			options.KeyColumn = types.StringValue(value)
 		case "key_in_value":
-			data.Options.KeyInValue = types.BoolValue(true)
+			options.KeyInValue = types.BoolValue(true)
 		case "lagging_ranges_threshold":
-			data.Options.LaggingRangesThreshold = types.StringValue(value)
+			options.LaggingRangesThreshold = types.StringValue(value)
 		case "lagging_ranges_polling_interval":
-			data.Options.LaggingRangesPollingInterval = types.StringValue(value)
+			options.LaggingRangesPollingInterval = types.StringValue(value)
 		case "metrics_label":
-			data.Options.MetricsLabel = types.StringValue(value)
+			options.MetricsLabel = types.StringValue(value)
 		case "min_checkpoint_frequency":
-			data.Options.MinCheckpointFrequency = types.StringValue(value)
+			options.MinCheckpointFrequency = types.StringValue(value)
 		case "mvcc_timestamp":
-			data.Options.MvccTimestamp = types.BoolValue(true)
+			options.MvccTimestamp = types.BoolValue(true)
 		case "on_error":
-			data.Options.OnError = types.StringValue(value)
+			options.OnError = types.StringValue(value)
 		case "protect_data_from_gc_on_pause":
-			data.Options.ProtectDataFromGcOnPause = types.BoolValue(true)
+			options.ProtectDataFromGcOnPause = types.BoolValue(true)
 		case "resolved":
-			data.Options.Resolved = types.StringValue(value)
+			options.Resolved = types.StringValue(value)
 		case "schema_change_events":
-			data.Options.SchemaChangeEvents = types.StringValue(value)
+			options.SchemaChangeEvents = types.StringValue(value)
 		case "schema_change_policy":
-			data.Options.SchemaChangePolicy = types.StringValue(value)
+			options.SchemaChangePolicy = types.StringValue(value)
 		case "split_column_families":
-			data.Options.SplitColumnFamilies = types.BoolValue(true)
+			options.SplitColumnFamilies = types.BoolValue(true)
 		case "topic_in_value":
-			data.Options.TopicInValue = types.BoolValue(true)
+			options.TopicInValue = types.BoolValue(true)
 		case "unordered":
-			data.Options.Unordered = types.BoolValue(true)
+			options.Unordered = types.BoolValue(true)
 		case "updated":
-			data.Options.Updated = types.BoolValue(true)
+			options.Updated = types.BoolValue(true)
 		case "virtual_columns":
-			data.Options.VirtualColumns = types.StringValue(value)
+			options.VirtualColumns = types.StringValue(value)
 		case "webhook_auth_header":
-			data.Options.WebhookAuthHeader = types.StringValue(value)
+			options.WebhookAuthHeader = types.StringValue(value)
 		case "webhook_sink_config":
-			data.Options.WebhookSinkConfig = types.StringValue(value)
+			options.WebhookSinkConfig = types.StringValue(value)
 		}
 	}
+}
 
-	data.Status = types.StringValue(changefeedInfo.status)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// changefeedDurationOptions are compared as durations, CockroachDB accepts both Go and interval syntax.
+var changefeedDurationOptions = []string{
+	"gc_protect_expires_after",
+	"lagging_ranges_polling_interval",
+	"lagging_ranges_threshold",
+	"min_checkpoint_frequency",
+	"resolved",
+}
+
+// changefeedJsonOptions are compared as JSON documents.
+var changefeedJsonOptions = []string{
+	"kafka_sink_config",
+	"webhook_sink_config",
+}
+
+// changefeedOptionEquivalent reports whether two values of an option configure the changefeed the same way.
+// Bool options are flags, so an absent flag is the same as false.
+func changefeedOptionEquivalent(tag string, a attr.Value, b attr.Value) bool {
+	switch aValue := a.(type) {
+	case types.Bool:
+		return aValue.ValueBool() == b.(types.Bool).ValueBool()
+	case types.String:
+		bValue := b.(types.String)
+		if aValue.IsNull() || bValue.IsNull() {
+			return aValue.IsNull() == bValue.IsNull()
+		}
+		return changefeedOptionValueEquivalent(tag, aValue.ValueString(), bValue.ValueString())
+	}
+	return a.Equal(b)
+}
+
+func changefeedOptionValueEquivalent(tag string, a string, b string) bool {
+	if a == b {
+		return true
+	}
+	switch {
+	case slices.Contains(changefeedDurationOptions, tag):
+		aDuration, errA := parseSettingDuration(a)
+		bDuration, errB := parseSettingDuration(b)
+		return errA == nil && errB == nil && aDuration == bDuration
+	case slices.Contains(changefeedJsonOptions, tag):
+		return jsonEquivalent(a, b)
+	}
+	return false
+}
+
+func jsonEquivalent(a string, b string) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// reconcileChangefeedOptions updates options with the current options of the changefeed, keeping the configured
+// representation of equivalent values. When managedOnly is set options that are not configured are left unset.
+func reconcileChangefeedOptions(options *ChangefeedOptionsModel, current ChangefeedOptionsModel, managedOnly bool) {
+	// Typed sink configs are compared in their JSON form
+	kafkaSinkSettings, webhookSinkSettings := options.KafkaSinkSettings, options.WebhookSinkSettings
+	*options = options.withRenderedSinkConfigs()
+	defer options.reconcileSinkSettings(kafkaSinkSettings, webhookSinkSettings)
+
+	optionsObjVal := reflect.ValueOf(options).Elem()
+	currentObjVal := reflect.ValueOf(current)
+	for i := 0; i < optionsObjVal.NumField(); i++ {
+		tag := optionsObjVal.Type().Field(i).Tag.Get("tfsdk")
+		value, ok := optionsObjVal.Field(i).Interface().(attr.Value)
+		if !ok {
+			continue
+		}
+		currentValue := currentObjVal.Field(i).Interface().(attr.Value)
+
+		if tag == "cursor" {
+			// The cursor is only known when the changefeed was created with one
+			if !currentValue.IsNull() {
+				optionsObjVal.Field(i).Set(reflect.ValueOf(currentValue))
+			}
+			continue
+		}
+
+		if managedOnly && value.IsNull() {
+			continue
+		}
+
+		if changefeedOptionEquivalent(tag, value, currentValue) {
+			continue
+		}
+
+		if boolValue, ok := currentValue.(types.Bool); ok {
+			currentValue = types.BoolValue(boolValue.ValueBool())
+		}
+		optionsObjVal.Field(i).Set(reflect.ValueOf(currentValue))
+	}
 }
 
 func stringListDelta(source []string, target []string) (added []string, removed []string) {
@@ -823,103 +1325,77 @@ func IsJobStatusRunning(status string) bool {
 	return status == "running" || status == "paused" || status == "pause-requested"
 }
 
-func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	bannedOptionUpdates := []string{
-		"end_time",
-		"full_table_name",
-		"initial_scan",
-	}
-
-	var data ChangefeedResourceModel
-	var stateData ChangefeedResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
-
-	data.Id = stateData.Id
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	stateStatus := stateData.Status.ValueString()
-
-	if !IsJobStatusRunning(stateStatus) {
-		resp.Diagnostics.AddError("Unable to update changefeed", "Changefeed is not running")
-		return
-	}
+func isJobStatusPaused(status string) bool {
+	return status == "paused" || status == "pause-requested"
+}
 
-	if !stateData.Select.IsNull() {
-		resp.Diagnostics.AddError("Unable to update changefeed", "Cannot update changefeed with select statement")
-		return
+// setChangefeedJobStatus pauses or resumes the changefeed job and waits until it reaches the status.
+func (r *ChangefeedResource) setChangefeedJobStatus(ctx context.Context, data *ChangefeedResourceModel, status string) error {
+	query := fmt.Sprintf("RESUME JOB %d", data.JobId.ValueInt64())
+	if status == "paused" {
+		query = fmt.Sprintf("PAUSE JOB %d WITH REASON='Terraform desired_status'", data.JobId.ValueInt64())
 	}
 
-	if !data.PersistentCursor.Equal(stateData.PersistentCursor) {
-		var err error
-		var cursorKey string
+	tflog.Info(ctx, fmt.Sprintf("Setting changefeed job status with query: %s", query))
 
-		if data.PersistentCursor.IsNull() {
-			_, cursorKey, err = ParseCursorId(stateData.PersistentCursor.ValueString())
-		} else {
-			_, cursorKey, err = ParseCursorId(data.PersistentCursor.ValueString())
-		}
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to update cursor job ID", err.Error())
-			return
-		}
-
-		if data.PersistentCursor.IsNull() {
-			err = UpdateCursorJobId(ctx, r.client, data.ClusterId.ValueString(), cursorKey, nil)
-		} else {
-			err = UpdateCursorJobId(ctx, r.client, data.ClusterId.ValueString(), cursorKey, data.JobId.ValueInt64Pointer())
-		}
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to update cursor job ID", err.Error())
-			return
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		if _, err := db.ExecEx(ctx, query, nil); err != nil {
+			return nil, err
 		}
+		return nil, waitForJobStatus(ctx, db, data.JobId.ValueInt64(), status)
+	})
+	if err != nil {
+		return err
 	}
 
-	alterCmds := tree.AlterChangefeedCmds{}
+	data.Status = types.StringValue(status)
+	return nil
+}
+
+// bannedOptionUpdates are options that cannot be changed with ALTER CHANGEFEED.
+var bannedOptionUpdates = []string{
+	"end_time",
+	"full_table_name",
+	"initial_scan",
+}
 
+// changefeedAlterCommands returns the ALTER CHANGEFEED commands that turn the changefeed in state into data, and a
+// description of every change that cannot be applied with ALTER CHANGEFEED.
+func changefeedAlterCommands(ctx context.Context, data *ChangefeedResourceModel, state ChangefeedResourceModel) (alterCmds tree.AlterChangefeedCmds, bannedChanges []string) {
 	// Build the options string
 	var setList []tree.KVOption
 	var unsetList []tree.Name
-	optionsObjVal := reflect.ValueOf(data.Options)
-	stateOptionsVal := reflect.ValueOf(stateData.Options)
+	// Typed sink configs and external connections are compared in the form sent to CockroachDB
+	optionsObjVal := reflect.ValueOf(data.effectiveOptions())
+	stateOptionsVal := reflect.ValueOf(state.effectiveOptions())
 	for i := 0; i < optionsObjVal.NumField(); i++ {
 		value, ok := optionsObjVal.Field(i).Interface().(attr.Value)
 		if !ok {
-			resp.Diagnostics.AddError("Unable to update changefeed", "Unable to parse options")
-			return
-		}
-
-		stateValue, ok := stateOptionsVal.Field(i).Interface().(attr.Value)
-		if !ok {
-			resp.Diagnostics.AddError("Unable to update changefeed", "Unable to parse options")
-			return
+			continue
 		}
+		stateValue := stateOptionsVal.Field(i).Interface().(attr.Value)
 
 		// get tfsdk tag
 		tag := optionsObjVal.Type().Field(i).Tag.Get("tfsdk")
 
 		if slices.Contains(bannedOptionUpdates, tag) {
-			if !value.Equal(stateValue) {
-				resp.Diagnostics.AddError("Unable to update changefeed", fmt.Sprintf("Cannot update %s option. old: %s new: %s", tag, stateValue.String(), value.String()))
-				return
+			if value.IsUnknown() || !changefeedOptionEquivalent(tag, value, stateValue) {
+				bannedChanges = append(bannedChanges, fmt.Sprintf("Cannot update %s option. old: %s new: %s", tag, stateValue.String(), value.String()))
 			}
 			continue
 		}
 
 		if tag == "cursor" {
-			data.Options.Cursor = stateData.Options.Cursor
+			data.Options.Cursor = state.Options.Cursor
 			continue
 		}
 
-		if value.Equal(stateValue) {
+		if !value.IsUnknown() && changefeedOptionEquivalent(tag, value, stateValue) {
 			continue
 		}
 
-		if value.IsNull() {
+		// Bool options are flags, disabling one unsets it
+		if value.IsNull() || value.Equal(types.BoolValue(false)) {
 			unsetList = append(unsetList, tree.Name(tag))
 		} else {
 			option := tree.KVOption{
@@ -933,7 +1409,7 @@ func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 	}
 
-	if !data.SinkUri.Equal(stateData.SinkUri) {
+	if !data.SinkUri.Equal(state.SinkUri) {
 		setList = append(setList, tree.KVOption{
 			Key:   tree.Name("sink_uri"),
 			Value: tree.NewStrVal(data.SinkUri.ValueString()),
@@ -952,20 +1428,19 @@ func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequ
 		})
 	}
 
-	// Get the added and removed targets
-	targets := make([]string, len(data.Target.Elements()))
-	data.Target.ElementsAs(ctx, &targets, false)
-	stateTargets := make([]string, len(stateData.Target.Elements()))
-	stateData.Target.ElementsAs(ctx, &stateTargets, false)
-	addedTargets, removedTargets := stringListDelta(stateTargets, targets)
+	// Get the added and removed targets, compared in their canonical form
+	targetsByKey := map[string]tree.ChangefeedTarget{}
+	targets, _ := data.changefeedTargets(ctx)
+	stateTargets, _ := state.changefeedTargets(ctx)
+	for _, target := range slices.Concat(targets, stateTargets) {
+		targetsByKey[changefeedTargetKey(target)] = target
+	}
+	addedTargets, removedTargets := stringListDelta(changefeedTargetKeys(stateTargets), changefeedTargetKeys(targets))
 
 	if len(addedTargets) > 0 {
 		changefeedTargets := tree.ChangefeedTargets{}
 		for _, target := range addedTargets {
-			targetParts := strings.Split(target, ".")
-			changefeedTargets = append(changefeedTargets, tree.ChangefeedTarget{
-				TableName: tree.NewTableNameWithSchema(tree.Name(targetParts[0]), tree.Name(targetParts[1]), tree.Name(targetParts[2])),
-			})
+			changefeedTargets = append(changefeedTargets, targetsByKey[target])
 		}
 
 		if data.InitialScanOnUpdate.IsNull() || !data.InitialScanOnUpdate.ValueBool() {
@@ -988,10 +1463,7 @@ func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequ
 	if len(removedTargets) > 0 {
 		changefeedTargets := tree.ChangefeedTargets{}
 		for _, target := range removedTargets {
-			targetParts := strings.Split(target, ".")
-			changefeedTargets = append(changefeedTargets, tree.ChangefeedTarget{
-				TableName: tree.NewTableNameWithSchema(tree.Name(targetParts[0]), tree.Name(targetParts[1]), tree.Name(targetParts[2])),
-			})
+			changefeedTargets = append(changefeedTargets, targetsByKey[target])
 		}
 
 		alterCmds = append(alterCmds, &tree.AlterChangefeedDropTarget{
@@ -999,78 +1471,335 @@ func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequ
 		})
 	}
 
-	if len(alterCmds) > 0 {
-		statement := tree.AlterChangefeed{
-			Jobs: tree.NewNumVal(
-				constant.MakeInt64(data.JobId.ValueInt64()),
-				fmt.Sprintf("%d", data.JobId.ValueInt64()),
-				false,
-			),
-			Cmds: alterCmds,
+	if data.Target.IsUnknown() || data.TargetFamily.IsUnknown() || data.SinkUri.IsUnknown() {
+		// The changes are only known at apply time, the changefeed has to be updated
+		bannedChanges = append(bannedChanges, "Targets or sink are unknown")
+	}
+
+	return alterCmds, bannedChanges
+}
+
+func (r *ChangefeedResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var sink *ChangefeedSinkModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("sink"), &sink)...)
+	if sink != nil {
+		if sink.IsKnown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sink_uri"), sink.Uri(ctx))...)
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sink_uri"), types.StringUnknown())...)
 		}
+	}
 
-		//query := fmt.Sprintf("ALTER CHANGEFEED %d %s %s %s %s", data.JobId.ValueInt64(), addTargetStatement, removeTargetStatement, setStatement, unsetStatement)
-		query := statement.String()
+	var sinkConnection types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("sink_external_connection"), &sinkConnection)...)
+	if !sinkConnection.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sink_uri"), externalConnectionRefUri(sinkConnection))...)
+	}
 
-		tflog.Info(ctx, fmt.Sprintf("Updating changefeed with query: %s", query))
+	// External connections are looked up when they change, so a wrong reference fails before the changefeed is touched
+	var clusterId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster_id"), &clusterId)...)
+	for _, connectionPath := range []path.Path{path.Root("sink_external_connection"), path.Root("schema_registry_external_connection")} {
+		var connectionId, stateConnectionId types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, connectionPath, &connectionId)...)
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, connectionPath, &stateConnectionId)...)
+		}
+		if r.client == nil || clusterId.IsUnknown() || connectionId.IsNull() || connectionId.IsUnknown() || connectionId.Equal(stateConnectionId) {
+			continue
+		}
+		resp.Diagnostics.Append(validateExternalConnectionRef(ctx, r.client, clusterId.ValueString(), connectionPath, connectionId.ValueString())...)
+	}
 
-		_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (_ *interface{}, err error) {
-			defer func() {
-				_, resumeErr := db.Exec(fmt.Sprintf("RESUME JOB %d", data.JobId.ValueInt64()))
-				if resumeErr != nil {
-					err = resumeErr
-				}
-				resumeErr = waitForJobStatus(db, data.JobId.ValueInt64(), "running")
-				if resumeErr != nil {
-					err = resumeErr
-				}
-			}()
+	var selectQuery types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("select"), &selectQuery)...)
+	switch {
+	case selectQuery.IsNull():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("select_table"), types.StringNull())...)
+	case selectQuery.IsUnknown():
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("select_table"), types.StringUnknown())...)
+	default:
+		if _, table, err := parseChangefeedSelect(selectQuery.ValueString()); err == nil {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("select_table"), table)...)
+		}
+	}
 
-			_, err = db.Exec(fmt.Sprintf("PAUSE JOB %d WITH REASON='Terraform Update'", data.JobId.ValueInt64()))
-			if err != nil {
-				return nil, err
-			}
-			// Wait until the job is paused
-			err = waitForJobStatus(db, data.JobId.ValueInt64(), "paused")
-			if err != nil {
-				return nil, err
-			}
+	if req.State.Raw.IsNull() {
+		return
+	}
 
-			_, err = db.Exec(query)
-			if err != nil {
-				return nil, err
+	var plan ChangefeedResourceModel
+	var state ChangefeedResourceModel
+
+	if diags := resp.Plan.Get(ctx, &plan); diags.HasError() {
+		return
+	}
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		return
+	}
+
+	// Computed attributes only become unknown when something else changes, so a failed job has to be replaced explicitly
+	if state.RecreateOnFailure.ValueBool() && !IsJobStatusRunning(state.Status.ValueString()) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("status"))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringUnknown())...)
+		return
+	}
+
+	if plan.UpdateStrategy.ValueString() != "recreate_from_cursor" || !state.Select.IsNull() {
+		return
+	}
+
+	// Recreating the changefeed replaces the job
+	alterCmds, bannedChanges := changefeedAlterCommands(ctx, &plan, state)
+	if len(alterCmds) == 0 && len(bannedChanges) == 0 {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("job_id"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("options").AtName("cursor"), types.StringUnknown())...)
+}
+
+func (r *ChangefeedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ChangefeedResourceModel
+	var stateData ChangefeedResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+
+	data.Id = stateData.Id
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	stateStatus := stateData.Status.ValueString()
+
+	if !IsJobStatusRunning(stateStatus) {
+		resp.Diagnostics.AddError("Unable to update changefeed", "Changefeed is not running")
+		return
+	}
+
+	if !stateData.Select.IsNull() {
+		// Only the formatting of the select statement and the job status can change in place
+		alterCmds, bannedChanges := changefeedAlterCommands(ctx, &data, stateData)
+		if len(alterCmds) > 0 || len(bannedChanges) > 0 {
+			resp.Diagnostics.AddError("Unable to update changefeed", "Cannot update changefeed with select statement")
+			return
+		}
+		data.Status = stateData.Status
+		if desired := data.DesiredStatus.ValueString(); desired != "" && isJobStatusPaused(stateStatus) != (desired == "paused") {
+			if err := r.setChangefeedJobStatus(ctx, &data, desired); err != nil {
+				resp.Diagnostics.AddError("Unable to set changefeed job status", err.Error())
+				return
 			}
+		}
+		if err := r.waitForChangefeed(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Changefeed did not reach the wait_for state", err.Error())
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
-			return nil, err
-		})
+	if !data.PersistentCursor.Equal(stateData.PersistentCursor) {
+		var err error
+		var cursor PersistentCursorRef
+
+		if data.PersistentCursor.IsNull() {
+			cursor, err = ParseCursorId(stateData.PersistentCursor.ValueString())
+		} else {
+			cursor, err = ParseCursorId(data.PersistentCursor.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to update cursor job ID", err.Error())
+			return
+		}
 
+		if data.PersistentCursor.IsNull() {
+			err = UpdateCursorJobId(ctx, r.client, cursor, nil)
+		} else {
+			err = UpdateCursorJobId(ctx, r.client, cursor, data.JobId.ValueInt64Pointer())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to update cursor job ID", err.Error())
+			return
+		}
+	}
+
+	data.Status = stateData.Status
+
+	// Pause before altering so the job is altered in place and stays paused
+	if data.DesiredStatus.ValueString() == "paused" && !isJobStatusPaused(stateStatus) {
+		if err := r.setChangefeedJobStatus(ctx, &data, "paused"); err != nil {
+			resp.Diagnostics.AddError("Unable to pause changefeed job", err.Error())
+			return
+		}
+		stateStatus = data.Status.ValueString()
+	}
+
+	alterCmds, bannedChanges := changefeedAlterCommands(ctx, &data, stateData)
+
+	switch {
+	case data.UpdateStrategy.ValueString() == "recreate_from_cursor" && (len(alterCmds) > 0 || len(bannedChanges) > 0):
+		err := r.recreateChangefeedFromCursor(ctx, &data, stateData)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to recreate changefeed", err.Error())
+			return
+		}
+		if data.DesiredStatus.ValueString() == "paused" {
+			if err := r.setChangefeedJobStatus(ctx, &data, "paused"); err != nil {
+				resp.Diagnostics.AddError("Unable to pause changefeed job", err.Error())
+				return
+			}
+		}
+	case len(bannedChanges) > 0:
+		resp.Diagnostics.AddError("Unable to update changefeed", strings.Join(bannedChanges, "\n"))
+		return
+	case len(alterCmds) > 0:
+		err := r.alterChangefeed(ctx, &data, stateStatus, alterCmds)
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to update changefeed", err.Error())
 			return
 		}
 	}
 
-	data.Status = types.StringValue("running")
+	if data.DesiredStatus.ValueString() == "running" && isJobStatusPaused(data.Status.ValueString()) {
+		if err := r.setChangefeedJobStatus(ctx, &data, "running"); err != nil {
+			resp.Diagnostics.AddError("Unable to resume changefeed job", err.Error())
+			return
+		}
+	}
+
+	if err := r.waitForChangefeed(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Changefeed did not reach the wait_for state", err.Error())
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func waitForJobStatus(db *pgx.ConnPool, jobId int64, status string) error {
-	return retry.Do(
-		func() error {
-			var jobStatus string
-			err := db.QueryRow(fmt.Sprintf("SELECT status FROM [SHOW CHANGEFEED JOB %d]", jobId)).Scan(&jobStatus)
-			if err != nil {
-				return err
+// alterChangefeed applies the ALTER CHANGEFEED commands. Running changefeeds are paused while they are altered,
+// changefeeds that are already paused are altered in place.
+func (r *ChangefeedResource) alterChangefeed(ctx context.Context, data *ChangefeedResourceModel, status string, alterCmds tree.AlterChangefeedCmds) error {
+	statement := tree.AlterChangefeed{
+		Jobs: tree.NewNumVal(
+			constant.MakeInt64(data.JobId.ValueInt64()),
+			fmt.Sprintf("%d", data.JobId.ValueInt64()),
+			false,
+		),
+		Cmds: alterCmds,
+	}
+
+	query := statement.String()
+
+	tflog.Info(ctx, fmt.Sprintf("Updating changefeed with query: %s", query))
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (_ *interface{}, err error) {
+		if isJobStatusPaused(status) {
+			_, err = db.ExecEx(ctx, query, nil)
+			return nil, err
+		}
+
+		defer func() {
+			_, resumeErr := db.ExecEx(ctx, fmt.Sprintf("RESUME JOB %d", data.JobId.ValueInt64()), nil)
+			if resumeErr != nil {
+				err = resumeErr
 			}
-			if jobStatus != status {
-				return fmt.Errorf("job status never reached %s current status: %s", status, jobStatus)
+			resumeErr = waitForJobStatus(ctx, db, data.JobId.ValueInt64(), "running")
+			if resumeErr != nil {
+				err = resumeErr
 			}
-			return nil
-		},
-		retry.Attempts(20),
-		retry.Delay(time.Second*2),
-	)
+		}()
+
+		_, err = db.ExecEx(ctx, fmt.Sprintf("PAUSE JOB %d WITH REASON='Terraform Update'", data.JobId.ValueInt64()), nil)
+		if err != nil {
+			return nil, err
+		}
+		// Wait until the job is paused
+		err = waitForJobStatus(ctx, db, data.JobId.ValueInt64(), "paused")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = db.ExecEx(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !isJobStatusPaused(status) {
+		data.Status = types.StringValue("running")
+	}
+
+	return nil
+}
+
+// recreateChangefeedFromCursor cancels the changefeed and creates a new one from its high-water timestamp.
+func (r *ChangefeedResource) recreateChangefeedFromCursor(ctx context.Context, data *ChangefeedResourceModel, state ChangefeedResourceModel) error {
+	jobId, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*int64, error) {
+		var highWaterTimestamp *string
+		err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT high_water_timestamp::STRING FROM [SHOW CHANGEFEED JOB %d]", state.JobId.ValueInt64()), nil).Scan(&highWaterTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		if highWaterTimestamp == nil {
+			return nil, fmt.Errorf("changefeed job %d has no high-water timestamp yet, it cannot be recreated from its cursor", state.JobId.ValueInt64())
+		}
+
+		data.Options.Cursor = types.StringValue(*highWaterTimestamp)
+		query := buildCreateChangefeedQuery(ctx, data)
+
+		_, err = db.ExecEx(ctx, fmt.Sprintf("CANCEL JOB %d", state.JobId.ValueInt64()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = waitForJobStatus(ctx, db, state.JobId.ValueInt64(), "canceled")
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Recreating changefeed with query: %s", query))
+
+		var jobId int64
+		err = db.QueryRowEx(ctx, query, nil).Scan(&jobId)
+		if err != nil {
+			return nil, fmt.Errorf("changefeed job %d was canceled but could not be recreated from cursor %s: %w", state.JobId.ValueInt64(), *highWaterTimestamp, err)
+		}
+
+		err = waitForJobStatus(ctx, db, jobId, "running")
+		return &jobId, err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	data.JobId = types.Int64Value(*jobId)
+	data.Id = types.StringValue(getChangefeedId(data.ClusterId.ValueString(), *jobId))
+	data.Status = types.StringValue("running")
+
+	if !data.PersistentCursor.IsNull() {
+		cursor, err := ParseCursorId(data.PersistentCursor.ValueString())
+		if err != nil {
+			return err
+		}
+		return UpdateCursorJobId(ctx, r.client, cursor, jobId)
+	}
+
+	return nil
 }
 
 func (r *ChangefeedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -1082,18 +1811,21 @@ func (r *ChangefeedResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	status := data.Status.ValueString()
 
 	if status == "running" || status == "paused" {
 
 		_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-			_, err := db.Exec(fmt.Sprintf("CANCEL JOB %d", data.JobId.ValueInt64()))
+			_, err := db.ExecEx(ctx, fmt.Sprintf("CANCEL JOB %d", data.JobId.ValueInt64()), nil)
 
 			if err != nil {
 				return nil, err
 			}
 
-			err = waitForJobStatus(db, data.JobId.ValueInt64(), "canceled")
+			err = waitForJobStatus(ctx, db, data.JobId.ValueInt64(), "canceled")
 
 			return nil, err
 		})
@@ -1107,3 +1839,48 @@ func (r *ChangefeedResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func (r *ChangefeedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 3 || idParts[0] != "changefeed" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format changefeed|<cluster_id>|<job_id>, got: %s", req.ID))
+		return
+	}
+
+	jobId, err := strconv.ParseInt(idParts[2], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Job ID must be a number, got: %s", idParts[2]))
+		return
+	}
+
+	changefeedInfo, err := r.getChangefeedJob(ctx, idParts[1], jobId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read changefeed job", err.Error())
+		return
+	}
+
+	if !IsJobStatusRunning(changefeedInfo.status) {
+		resp.Diagnostics.AddError("Unable to import changefeed", fmt.Sprintf("Changefeed job is in state: %s", changefeedInfo.status))
+		return
+	}
+
+	data := ChangefeedResourceModel{
+		ClusterId:                        types.StringValue(idParts[1]),
+		Id:                               types.StringValue(getChangefeedId(idParts[1], jobId)),
+		JobId:                            types.Int64Value(jobId),
+		Target:                           types.ListNull(types.StringType),
+		TargetFamily:                     types.ListNull(types.ObjectType{AttrTypes: changefeedTargetFamilyAttrTypes}),
+		Select:                           types.StringNull(),
+		SelectTable:                      types.StringNull(),
+		InitialScanOnUpdate:              types.BoolNull(),
+		UpdateStrategy:                   types.StringValue("pause_alter_resume"),
+		RecreateOnFailure:                types.BoolValue(false),
+		PersistentCursor:                 types.StringNull(),
+		SinkExternalConnection:           types.StringNull(),
+		SchemaRegistryExternalConnection: types.StringNull(),
+		Timeouts:                         nullTimeouts(),
+	}
+	data.applyChangefeedJob(ctx, changefeedInfo)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
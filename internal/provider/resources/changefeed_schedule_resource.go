@@ -0,0 +1,485 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &ChangefeedScheduleResource{}
+var _ resource.ResourceWithImportState = &ChangefeedScheduleResource{}
+var _ resource.ResourceWithConfigValidators = &ChangefeedScheduleResource{}
+
+func NewChangefeedScheduleResource() resource.Resource {
+	return &ChangefeedScheduleResource{}
+}
+
+type ChangefeedScheduleResource struct {
+	client *ccloud.CcloudClient
+}
+
+type ChangefeedScheduleResourceModel struct {
+	ClusterId       types.String           `tfsdk:"cluster_id"`
+	Id              types.String           `tfsdk:"id"`
+	Label           types.String           `tfsdk:"label"`
+	ScheduleId      types.Int64            `tfsdk:"schedule_id"`
+	Target          types.List             `tfsdk:"target"`
+	Select          types.String           `tfsdk:"select"`
+	SinkUri         types.String           `tfsdk:"sink_uri"`
+	Recurring       types.String           `tfsdk:"recurring"`
+	Options         ChangefeedOptionsModel `tfsdk:"options"`
+	ScheduleOptions *struct {
+		FirstRun           types.String `tfsdk:"first_run"`
+		OnExecutionFailure types.String `tfsdk:"on_execution_failure"`
+		OnPreviousRunning  types.String `tfsdk:"on_previous_running"`
+	} `tfsdk:"schedule_options"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func getChangefeedScheduleId(clusterId string, label string) string {
+	return fmt.Sprintf("changefeed_schedule|%s|%s", clusterId, label)
+}
+
+func (r *ChangefeedScheduleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		&avroConfluentValidator{},
+		&keyColumnValidator{},
+	}
+}
+
+func (r *ChangefeedScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_changefeed_schedule"
+}
+
+func (r *ChangefeedScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Scheduled changefeed that periodically exports the watched tables with ` + "`CREATE SCHEDULE FOR CHANGEFEED`" + `.
+Scheduled changefeeds cannot be altered, changing any attribute recreates the schedule.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the changefeed schedule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Label for the changefeed schedule",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schedule_id": schema.Int64Attribute{
+				MarkdownDescription: "Schedule ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"target": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of tables that the changefeed will export",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.ExactlyOneOf(path.MatchRoot("select")),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						ChangefeedTableValidator(),
+					),
+				},
+			},
+			"select": schema.StringAttribute{
+				MarkdownDescription: "SQL query that the changefeed will use to filter the exported table",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("target")),
+					ChangefeedSelectValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					changefeedSelectRequiresReplace(),
+				},
+			},
+			"sink_uri": schema.StringAttribute{
+				MarkdownDescription: "URI of the sink where the changefeed will export the rows",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					SinkUriValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"recurring": schema.StringAttribute{
+				MarkdownDescription: "Recurring schedule",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf("@daily", "@hourly", "@weekly"),
+						CronExpressionValidator(),
+					),
+				},
+			},
+			"options": schema.SingleNestedAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: `
+Options for the changefeed.
+Documentation for the options can be found [here](https://www.cockroachlabs.com/docs/stable/create-schedule-for-changefeed#changefeed-options)
+`,
+				Attributes: changefeedOptionsAttributes(),
+			},
+			"schedule_options": schema.SingleNestedAttribute{
+				MarkdownDescription: "Changefeed schedule options",
+				Optional:            true,
+				Computed:            true,
+				Default: objectdefault.StaticValue(types.ObjectValueMust(
+					map[string]attr.Type{
+						"first_run":            types.StringType,
+						"on_execution_failure": types.StringType,
+						"on_previous_running":  types.StringType,
+					},
+					map[string]attr.Value{
+						"first_run":            types.StringNull(),
+						"on_execution_failure": types.StringValue("reschedule"),
+						"on_previous_running":  types.StringValue("wait"),
+					},
+				)),
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"first_run": schema.StringAttribute{
+						MarkdownDescription: "When should the first run be scheduled",
+						Optional:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"on_execution_failure": schema.StringAttribute{
+						MarkdownDescription: "What to do on execution failure",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("retry", "reschedule", "pause"),
+						},
+						Default:  stringdefault.StaticString("reschedule"),
+						Computed: true,
+					},
+					"on_previous_running": schema.StringAttribute{
+						MarkdownDescription: "What to do if the previous run is still running",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("skip", "wait", "start"),
+						},
+						Default:  stringdefault.StaticString("wait"),
+						Computed: true,
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ChangefeedScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ChangefeedScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ChangefeedScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	// Scheduled changefeeds always start from the time of each run
+	data.Options.Cursor = types.StringNull()
+
+	header := fmt.Sprintf("CREATE SCHEDULE %s FOR CHANGEFEED", pgx.Identifier{data.Label.ValueString()}.Sanitize())
+	optionsString := buildChangefeedOptions(data.Options)
+	sink := fmt.Sprintf("INTO %s", SanatizeValue(data.SinkUri.ValueString()))
+
+	var changefeed string
+	if !data.Target.IsNull() {
+		var targets []string
+		data.Target.ElementsAs(ctx, &targets, false)
+		changefeed = fmt.Sprintf("%s %s %s %s", header, strings.Join(targets, ", "), sink, optionsString)
+	} else {
+		changefeed = fmt.Sprintf("%s %s %s AS %s", header, sink, optionsString, data.Select.ValueString())
+	}
+
+	recurring := fmt.Sprintf("RECURRING %s", SanatizeValue(data.Recurring.ValueString()))
+
+	scheduleOptions := ""
+	if scheduleOptionsSet := buildScheduleOptions(data.ScheduleOptions.FirstRun, data.ScheduleOptions.OnExecutionFailure, data.ScheduleOptions.OnPreviousRunning); len(scheduleOptionsSet) > 0 {
+		scheduleOptions = fmt.Sprintf("WITH SCHEDULE OPTIONS %s", strings.Join(scheduleOptionsSet, ", "))
+	}
+
+	createScheduleQuery := fmt.Sprintf("%s %s %s", changefeed, recurring, scheduleOptions)
+	fullQuery := fmt.Sprintf("WITH x as (%s) select schedule_id from x", createScheduleQuery)
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating changefeed schedule: %s", fullQuery))
+
+	scheduleId, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*int64, error) {
+		var exists bool
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT * FROM [SHOW SCHEDULES FOR CHANGEFEED] WHERE label = $1)", nil, data.Label.ValueString()).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("changefeed schedule with label %s already exists", data.Label.ValueString())
+		}
+
+		var scheduleId int64
+		err = db.QueryRowEx(ctx, fullQuery, nil).Scan(&scheduleId)
+		return &scheduleId, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create changefeed schedule", err.Error())
+		return
+	}
+
+	data.ScheduleId = types.Int64Value(*scheduleId)
+	data.Id = types.StringValue(getChangefeedScheduleId(data.ClusterId.ValueString(), data.Label.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type ChangefeedScheduleNotFoundError struct {
+	Label string
+}
+
+func (e ChangefeedScheduleNotFoundError) Error() string {
+	return fmt.Sprintf("changefeed schedule %s not found", e.Label)
+}
+
+type changefeedScheduleInfo struct {
+	id                 int64
+	recurrence         string
+	onPreviousRunning  string
+	onExecutionFailure string
+	command            *tree.CreateChangefeed
+}
+
+func (r *ChangefeedScheduleResource) getChangefeedSchedule(ctx context.Context, clusterId string, label string) (*changefeedScheduleInfo, error) {
+	return ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*changefeedScheduleInfo, error) {
+		info := changefeedScheduleInfo{}
+		var command string
+		err := db.QueryRowEx(ctx, "SELECT id, recurrence, on_previous_running, on_execution_failure, command FROM [SHOW SCHEDULES FOR CHANGEFEED] WHERE label = $1", nil, label).
+			Scan(&info.id, &info.recurrence, &info.onPreviousRunning, &info.onExecutionFailure, &command)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ChangefeedScheduleNotFoundError{Label: label}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parsedCommand, err := parser.ParseOne(command)
+		if err != nil {
+			return nil, err
+		}
+		changefeedCommand, ok := parsedCommand.AST.(*tree.CreateChangefeed)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse changefeed command")
+		}
+		info.command = changefeedCommand
+
+		return &info, nil
+	})
+}
+
+// applySchedule updates data from the schedule. When all is false only configured changefeed options are updated.
+func (data *ChangefeedScheduleResourceModel) applySchedule(ctx context.Context, schedule *changefeedScheduleInfo, all bool) {
+	data.ScheduleId = types.Int64Value(schedule.id)
+	data.Recurring = types.StringValue(schedule.recurrence)
+	data.ScheduleOptions.OnPreviousRunning = types.StringValue(scheduleOnPreviousRunning(schedule.onPreviousRunning))
+	data.ScheduleOptions.OnExecutionFailure = types.StringValue(scheduleOnExecutionFailure(schedule.onExecutionFailure))
+
+	if schedule.command.Select != nil {
+		// Keep the configured formatting as long as it parses to the same query
+		currentSelect := schedule.command.Select.String()
+		if data.Select.IsNull() || !changefeedSelectEquivalent(data.Select.ValueString(), currentSelect) {
+			data.Select = types.StringValue(currentSelect)
+		}
+		data.Target = types.ListNull(types.StringType)
+	} else {
+		// Keep the configured formatting and order as long as the tables are the same
+		var tables []string
+		data.Target.ElementsAs(ctx, &tables, false)
+		if configured, err := parseChangefeedTables(tables); err != nil || !changefeedTargetsEquivalent(configured, schedule.command.Targets) {
+			targets := make([]attr.Value, len(schedule.command.Targets))
+			for i, target := range schedule.command.Targets {
+				targets[i] = types.StringValue(target.TableName.String())
+			}
+			data.Target, _ = types.ListValue(types.StringType, targets)
+		}
+		data.Select = types.StringNull()
+	}
+
+	sinkUri := removeQuotes(schedule.command.SinkURI.String())
+	if !CompareURLs(data.SinkUri.ValueString(), sinkUri) {
+		data.SinkUri = types.StringValue(sinkUri)
+	}
+
+	current := ChangefeedOptionsModel{}
+	current.applyKVOptions(schedule.command.Options)
+	reconcileChangefeedOptions(&data.Options, current, !all)
+	data.Options.Cursor = types.StringNull()
+}
+
+func (r *ChangefeedScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChangefeedScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	schedule, err := r.getChangefeedSchedule(ctx, data.ClusterId.ValueString(), data.Label.ValueString())
+	if err != nil {
+		var notFound ChangefeedScheduleNotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read changefeed schedule", err.Error())
+		return
+	}
+
+	data.applySchedule(ctx, schedule, false)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChangefeedScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, there is nothing to update in place
+	var data ChangefeedScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChangefeedScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ChangefeedScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, fmt.Sprintf("DROP SCHEDULE %d", data.ScheduleId.ValueInt64()), nil)
+		return nil, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to delete changefeed schedule", err.Error())
+		return
+	}
+}
+
+func (r *ChangefeedScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 3 || idParts[0] != "changefeed_schedule" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format changefeed_schedule|<cluster_id>|<label>, got: %s", req.ID))
+		return
+	}
+
+	schedule, err := r.getChangefeedSchedule(ctx, idParts[1], idParts[2])
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read changefeed schedule", err.Error())
+		return
+	}
+
+	data := ChangefeedScheduleResourceModel{
+		ClusterId: types.StringValue(idParts[1]),
+		Id:        types.StringValue(req.ID),
+		Label:     types.StringValue(idParts[2]),
+		ScheduleOptions: &struct {
+			FirstRun           types.String `tfsdk:"first_run"`
+			OnExecutionFailure types.String `tfsdk:"on_execution_failure"`
+			OnPreviousRunning  types.String `tfsdk:"on_previous_running"`
+		}{},
+		Timeouts: nullTimeouts(),
+	}
+	data.applySchedule(ctx, schedule, true)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,167 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// changefeedAggregateFunctions cannot be used in changefeed queries, rows are emitted one at a time.
+var changefeedAggregateFunctions = []string{
+	"array_agg", "array_cat_agg", "avg", "bit_and", "bit_or", "bool_and", "bool_or", "concat_agg", "corr", "count",
+	"count_rows", "covar_pop", "covar_samp", "every", "json_agg", "json_object_agg", "jsonb_agg", "jsonb_object_agg",
+	"max", "min", "percentile_cont", "percentile_disc", "regr_avgx", "regr_avgy", "regr_count", "regr_intercept",
+	"regr_r2", "regr_slope", "regr_sxx", "regr_sxy", "regr_syy", "sqrdiff", "st_collect", "st_extent",
+	"st_makeline", "st_memcollect", "st_memunion", "st_union", "stddev", "stddev_pop", "stddev_samp", "string_agg",
+	"sum", "sum_int", "var_pop", "var_samp", "variance", "xor_agg",
+}
+
+type changefeedSelectVisitor struct {
+	err error
+}
+
+func (v *changefeedSelectVisitor) VisitPre(expr tree.Expr) (bool, tree.Expr) {
+	switch e := expr.(type) {
+	case *tree.Subquery:
+		v.err = fmt.Errorf("subqueries are not supported in changefeed queries")
+	case *tree.FuncExpr:
+		name := e.Func.String()
+		name = removeQuotes(strings.ToLower(name[strings.LastIndex(name, ".")+1:]))
+		// The parser marks every plain function call as GeneralAgg, so only the aggregate specific syntax is checked
+		if e.WindowDef != nil {
+			v.err = fmt.Errorf("window functions are not supported in changefeed queries")
+		} else if e.Filter != nil || e.AggType == tree.OrderedSetAgg || len(e.OrderBy) > 0 || slices.Contains(changefeedAggregateFunctions, name) {
+			v.err = fmt.Errorf("aggregate function %s is not supported in changefeed queries", name)
+		}
+	}
+	return v.err == nil, expr
+}
+
+func (v *changefeedSelectVisitor) VisitPost(expr tree.Expr) tree.Expr {
+	return expr
+}
+
+// parseChangefeedSelect parses a changefeed query and returns the normalized query and the table it selects from.
+// Constructs that CockroachDB does not support in changefeed queries are rejected.
+func parseChangefeedSelect(query string) (normalized string, table string, err error) {
+	statement, err := parser.ParseOne(query)
+	if err != nil {
+		return "", "", err
+	}
+
+	selectStatement, ok := statement.AST.(*tree.Select)
+	if !ok {
+		return "", "", fmt.Errorf("changefeed query must be a SELECT statement")
+	}
+	switch {
+	case selectStatement.With != nil:
+		return "", "", fmt.Errorf("WITH is not supported in changefeed queries")
+	case selectStatement.OrderBy != nil:
+		return "", "", fmt.Errorf("ORDER BY is not supported in changefeed queries")
+	case selectStatement.Limit != nil:
+		return "", "", fmt.Errorf("LIMIT is not supported in changefeed queries")
+	case selectStatement.Locking != nil:
+		return "", "", fmt.Errorf("locking clauses are not supported in changefeed queries")
+	}
+
+	clause, ok := selectStatement.Select.(*tree.SelectClause)
+	if !ok {
+		return "", "", fmt.Errorf("changefeed query must be a single SELECT ... FROM ... WHERE ... statement")
+	}
+	switch {
+	case clause.Distinct || clause.DistinctOn != nil:
+		return "", "", fmt.Errorf("DISTINCT is not supported in changefeed queries")
+	case clause.GroupBy != nil || clause.Having != nil:
+		return "", "", fmt.Errorf("aggregation is not supported in changefeed queries")
+	case clause.Window != nil:
+		return "", "", fmt.Errorf("window functions are not supported in changefeed queries")
+	case len(clause.From.Tables) != 1:
+		return "", "", fmt.Errorf("changefeed query must select from exactly one table")
+	}
+
+	aliasedTable, ok := clause.From.Tables[0].(*tree.AliasedTableExpr)
+	if !ok {
+		return "", "", fmt.Errorf("joins are not supported in changefeed queries")
+	}
+	switch tableExpr := aliasedTable.Expr.(type) {
+	case *tree.TableName:
+		table = tableExpr.String()
+	case *tree.UnresolvedObjectName:
+		tableName := tableExpr.ToTableName()
+		table = tableName.String()
+	case *tree.Subquery:
+		return "", "", fmt.Errorf("subqueries are not supported in changefeed queries")
+	default:
+		return "", "", fmt.Errorf("changefeed query must select from a table")
+	}
+
+	visitor := changefeedSelectVisitor{}
+	for _, selectExpr := range clause.Exprs {
+		tree.WalkExprConst(&visitor, selectExpr.Expr)
+		if visitor.err != nil {
+			return "", "", visitor.err
+		}
+	}
+	if clause.Where != nil {
+		tree.WalkExprConst(&visitor, clause.Where.Expr)
+		if visitor.err != nil {
+			return "", "", visitor.err
+		}
+	}
+
+	return statement.AST.String(), table, nil
+}
+
+// changefeedSelectEquivalent reports whether two changefeed queries only differ in formatting.
+func changefeedSelectEquivalent(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	aStatement, errA := parser.ParseOne(a)
+	bStatement, errB := parser.ParseOne(b)
+	return errA == nil && errB == nil && aStatement.AST.String() == bStatement.AST.String()
+}
+
+type changefeedSelectValidator struct{}
+
+func (v changefeedSelectValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v changefeedSelectValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a changefeed query selecting from a single table, without joins, aggregates, subqueries, ORDER BY or LIMIT"
+}
+
+func (v changefeedSelectValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, _, err := parseChangefeedSelect(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			err.Error(),
+		))
+	}
+}
+
+func ChangefeedSelectValidator() validator.String {
+	return changefeedSelectValidator{}
+}
+
+// changefeedSelectRequiresReplace requires replacement unless the query only changed formatting.
+func changefeedSelectRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() ||
+			!changefeedSelectEquivalent(req.StateValue.ValueString(), req.PlanValue.ValueString())
+	}, "Changing the query of a changefeed requires replacement, formatting changes are applied in place", "Changing the query of a changefeed requires replacement, formatting changes are applied in place")
+}
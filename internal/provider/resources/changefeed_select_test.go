@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseChangefeedSelect(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		normalized string
+		table      string
+	}{
+		{
+			name:       "projection and filter",
+			query:      "select id, status from db.public.orders where status != 'draft'",
+			normalized: "SELECT id, status FROM db.public.orders WHERE status != 'draft'",
+			table:      "db.public.orders",
+		},
+		{
+			name:       "star",
+			query:      "SELECT * FROM orders",
+			normalized: "SELECT * FROM orders",
+			table:      "orders",
+		},
+		{
+			name:       "scalar functions",
+			query:      "SELECT id, lower(email) AS email, cdc_prev FROM db.public.users",
+			normalized: "SELECT id, lower(email) AS email, cdc_prev FROM db.public.users",
+			table:      "db.public.users",
+		},
+		{
+			name:       "quoted table",
+			query:      `SELECT * FROM db.public."Order Items"`,
+			normalized: `SELECT * FROM db.public."Order Items"`,
+			table:      `db.public."Order Items"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			normalized, table, err := parseChangefeedSelect(test.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if normalized != test.normalized {
+				t.Errorf("expected query %q, got %q", test.normalized, normalized)
+			}
+			if table != test.table {
+				t.Errorf("expected table %q, got %q", test.table, table)
+			}
+		})
+	}
+}
+
+func TestParseChangefeedSelectInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		err   string
+	}{
+		{"not a select", "DELETE FROM orders", "must be a SELECT statement"},
+		{"with", "WITH o AS (SELECT * FROM orders) SELECT * FROM o", "WITH is not supported"},
+		{"order by", "SELECT * FROM orders ORDER BY id", "ORDER BY is not supported"},
+		{"limit", "SELECT * FROM orders LIMIT 1", "LIMIT is not supported"},
+		{"locking", "SELECT * FROM orders FOR UPDATE", "locking clauses are not supported"},
+		{"union", "SELECT * FROM orders UNION SELECT * FROM items", "single SELECT"},
+		{"distinct", "SELECT DISTINCT status FROM orders", "DISTINCT is not supported"},
+		{"group by", "SELECT status FROM orders GROUP BY status", "aggregation is not supported"},
+		{"aggregate", "SELECT count(*) FROM orders", "aggregate function count"},
+		{"window", "SELECT row_number() OVER () FROM orders", "window functions are not supported"},
+		{"several tables", "SELECT * FROM orders, items", "exactly one table"},
+		{"join", "SELECT * FROM orders JOIN items ON orders.id = items.order_id", "joins are not supported"},
+		{"subquery in from", "SELECT * FROM (SELECT * FROM orders)", "subqueries are not supported"},
+		{"subquery in where", "SELECT * FROM orders WHERE id IN (SELECT order_id FROM items)", "subqueries are not supported"},
+		{"syntax error", "SELECT * FROM", "syntax error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := parseChangefeedSelect(test.query)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestChangefeedSelectEquivalent(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{"identical", "SELECT * FROM orders", "SELECT * FROM orders", true},
+		{"case and whitespace", "select *\n  from orders\n  where id > 1", "SELECT * FROM orders WHERE id > 1", true},
+		{"needless quotes", `SELECT "id" FROM "orders"`, "SELECT id FROM orders", true},
+		{"different filter", "SELECT * FROM orders WHERE id > 1", "SELECT * FROM orders WHERE id > 2", false},
+		{"different table", "SELECT * FROM orders", "SELECT * FROM items", false},
+		{"invalid", "SELECT * FROM", "SELECT * FROM orders", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := changefeedSelectEquivalent(test.a, test.b); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
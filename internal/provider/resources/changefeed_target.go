@@ -0,0 +1,165 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ChangefeedTargetFamilyModel struct {
+	Table  types.String `tfsdk:"table"`
+	Family types.String `tfsdk:"family"`
+}
+
+var changefeedTargetFamilyAttrTypes = map[string]attr.Type{
+	"table":  types.StringType,
+	"family": types.StringType,
+}
+
+// parseChangefeedTable parses a table name with the changefeed target grammar, so quoted and unicode names are
+// accepted. The name has to be fully qualified.
+func parseChangefeedTable(table string) (tree.ChangefeedTarget, error) {
+	statement, err := parser.ParseOne("CREATE CHANGEFEED FOR TABLE " + table)
+	if err != nil {
+		return tree.ChangefeedTarget{}, fmt.Errorf("%q is not a valid table name", table)
+	}
+
+	changefeed, ok := statement.AST.(*tree.CreateChangefeed)
+	if !ok || len(changefeed.Targets) != 1 || changefeed.SinkURI != nil || len(changefeed.Options) > 0 || changefeed.Targets[0].FamilyName != "" {
+		return tree.ChangefeedTarget{}, fmt.Errorf("%q is not a single table name", table)
+	}
+
+	name, ok := changefeed.Targets[0].TableName.(*tree.UnresolvedName)
+	if !ok || name.Star || name.NumParts != 3 {
+		return tree.ChangefeedTarget{}, fmt.Errorf("table name %q must be fully qualified as database.schema.table", table)
+	}
+
+	return changefeed.Targets[0], nil
+}
+
+// changefeedTargetKey is the canonical form of a target, as CockroachDB renders it in the job description.
+func changefeedTargetKey(target tree.ChangefeedTarget) string {
+	return tree.AsString(&target)
+}
+
+// changefeedTargets returns the targets configured with target and target_family, in order.
+func (data *ChangefeedResourceModel) changefeedTargets(ctx context.Context) (tree.ChangefeedTargets, error) {
+	var tables []string
+	var families []ChangefeedTargetFamilyModel
+	if diags := data.Target.ElementsAs(ctx, &tables, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read targets")
+	}
+	if diags := data.TargetFamily.ElementsAs(ctx, &families, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read target families")
+	}
+
+	targets, err := parseChangefeedTables(tables)
+	if err != nil {
+		return nil, err
+	}
+	for _, family := range families {
+		target, err := parseChangefeedTable(family.Table.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		target.FamilyName = tree.Name(family.Family.ValueString())
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// parseChangefeedTables parses a list of fully qualified table names into changefeed targets.
+func parseChangefeedTables(tables []string) (tree.ChangefeedTargets, error) {
+	targets := tree.ChangefeedTargets{}
+	for _, table := range tables {
+		target, err := parseChangefeedTable(table)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// changefeedTargetsEquivalent reports whether both lists watch the same targets, regardless of order and quoting.
+func changefeedTargetsEquivalent(a tree.ChangefeedTargets, b tree.ChangefeedTargets) bool {
+	aKeys := changefeedTargetKeys(a)
+	bKeys := changefeedTargetKeys(b)
+	slices.Sort(aKeys)
+	slices.Sort(bKeys)
+	return slices.Equal(aKeys, bKeys)
+}
+
+func changefeedTargetKeys(targets tree.ChangefeedTargets) []string {
+	keys := make([]string, len(targets))
+	for i, target := range targets {
+		keys[i] = changefeedTargetKey(target)
+	}
+	return keys
+}
+
+// applyChangefeedTargets updates target and target_family from the targets of the changefeed. The configured
+// formatting and order is kept as long as the targets are the same.
+func (data *ChangefeedResourceModel) applyChangefeedTargets(ctx context.Context, current tree.ChangefeedTargets) {
+	if configured, err := data.changefeedTargets(ctx); err == nil && changefeedTargetsEquivalent(configured, current) {
+		return
+	}
+
+	tables := []attr.Value{}
+	families := []attr.Value{}
+	for _, target := range current {
+		if target.FamilyName == "" {
+			tables = append(tables, types.StringValue(tree.AsString(target.TableName)))
+			continue
+		}
+		family, _ := types.ObjectValue(changefeedTargetFamilyAttrTypes, map[string]attr.Value{
+			"table":  types.StringValue(tree.AsString(target.TableName)),
+			"family": types.StringValue(string(target.FamilyName)),
+		})
+		families = append(families, family)
+	}
+
+	data.Target = types.ListNull(types.StringType)
+	if len(tables) > 0 {
+		data.Target, _ = types.ListValue(types.StringType, tables)
+	}
+	data.TargetFamily = types.ListNull(types.ObjectType{AttrTypes: changefeedTargetFamilyAttrTypes})
+	if len(families) > 0 {
+		data.TargetFamily, _ = types.ListValue(types.ObjectType{AttrTypes: changefeedTargetFamilyAttrTypes}, families)
+	}
+}
+
+type changefeedTableValidator struct{}
+
+func (v changefeedTableValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v changefeedTableValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a fully qualified table name, quoted where SQL requires it"
+}
+
+func (v changefeedTableValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseChangefeedTable(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			err.Error(),
+		))
+	}
+}
+
+func ChangefeedTableValidator() validator.String {
+	return changefeedTableValidator{}
+}
@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+)
+
+func TestParseChangefeedTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    string
+		expected string
+	}{
+		{"qualified", "db.public.orders", "TABLE db.public.orders"},
+		{"quoted", `db.public."Order Items"`, `TABLE db.public."Order Items"`},
+		{"unicode", "db.public.bestellungen_ä", "TABLE db.public.bestellungen_ä"},
+		{"needless quotes", `"db".public."orders"`, "TABLE db.public.orders"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := parseChangefeedTable(test.table)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if actual := changefeedTargetKey(target); actual != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseChangefeedTableInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+	}{
+		{"unqualified", "orders"},
+		{"missing schema", "db.orders"},
+		{"several tables", "db.public.orders, db.public.items"},
+		{"family", "db.public.orders FAMILY f"},
+		{"sink", "db.public.orders INTO 'kafka://broker'"},
+		{"options", "db.public.orders WITH resolved"},
+		{"star", "db.public.*"},
+		{"syntax error", "db.public.orders)"},
+		{"empty", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if target, err := parseChangefeedTable(test.table); err == nil {
+				t.Errorf("expected an error, got %s", changefeedTargetKey(target))
+			}
+		})
+	}
+}
+
+func TestChangefeedTargetsEquivalent(t *testing.T) {
+	target := func(table string, family string) tree.ChangefeedTarget {
+		parsed, err := parseChangefeedTable(table)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		parsed.FamilyName = tree.Name(family)
+		return parsed
+	}
+
+	tests := []struct {
+		name     string
+		a        tree.ChangefeedTargets
+		b        tree.ChangefeedTargets
+		expected bool
+	}{
+		{
+			name:     "same order",
+			a:        tree.ChangefeedTargets{target("db.public.a", ""), target("db.public.b", "")},
+			b:        tree.ChangefeedTargets{target("db.public.a", ""), target("db.public.b", "")},
+			expected: true,
+		},
+		{
+			name:     "different order",
+			a:        tree.ChangefeedTargets{target("db.public.a", ""), target("db.public.b", "")},
+			b:        tree.ChangefeedTargets{target("db.public.b", ""), target("db.public.a", "")},
+			expected: true,
+		},
+		{
+			name:     "different quoting",
+			a:        tree.ChangefeedTargets{target(`"db".public."a"`, "")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "")},
+			expected: true,
+		},
+		{
+			name:     "family",
+			a:        tree.ChangefeedTargets{target("db.public.a", "primary")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "primary")},
+			expected: true,
+		},
+		{
+			name:     "different family",
+			a:        tree.ChangefeedTargets{target("db.public.a", "primary")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "secondary")},
+			expected: false,
+		},
+		{
+			name:     "family and whole table",
+			a:        tree.ChangefeedTargets{target("db.public.a", "primary")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "")},
+			expected: false,
+		},
+		{
+			name:     "missing table",
+			a:        tree.ChangefeedTargets{target("db.public.a", ""), target("db.public.b", "")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "")},
+			expected: false,
+		},
+		{
+			name:     "case of quoted names",
+			a:        tree.ChangefeedTargets{target(`db.public."A"`, "")},
+			b:        tree.ChangefeedTargets{target("db.public.a", "")},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := changefeedTargetsEquivalent(test.a, test.b); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -2,12 +2,19 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackc/pgx"
@@ -17,6 +24,7 @@ import (
 
 var _ resource.Resource = &ClusterSettingResource{}
 var _ resource.ResourceWithImportState = &ClusterSettingResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterSettingResource{}
 
 func NewClusterSettingResource() resource.Resource {
 	return &ClusterSettingResource{}
@@ -27,10 +35,35 @@ type ClusterSettingResource struct {
 }
 
 type ClusterSettingResourceModel struct {
-	ClusterId    types.String `tfsdk:"cluster_id"`
-	SettingName  types.String `tfsdk:"setting_name"`
-	SettingValue types.String `tfsdk:"setting_value"`
-	Id           types.String `tfsdk:"id"`
+	ClusterId      types.String   `tfsdk:"cluster_id"`
+	SettingName    types.String   `tfsdk:"setting_name"`
+	SettingValue   types.String   `tfsdk:"setting_value"`
+	SettingType    types.String   `tfsdk:"setting_type"`
+	VirtualCluster types.String   `tfsdk:"virtual_cluster"`
+	OnDestroy      types.String   `tfsdk:"on_destroy"`
+	Id             types.String   `tfsdk:"id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func buildClusterSettingId(clusterId string, virtualCluster string, settingName string) string {
+	if virtualCluster == "" {
+		return fmt.Sprintf("%s|%s", clusterId, settingName)
+	}
+	return fmt.Sprintf("%s|%s|%s", clusterId, virtualCluster, settingName)
+}
+
+const (
+	clusterSettingOnDestroyReset           = "reset"
+	clusterSettingOnDestroyRestorePrevious = "restore_previous"
+	clusterSettingOnDestroyKeep            = "keep"
+)
+
+// Private state key holding the value the setting had before it was managed by terraform.
+const clusterSettingPreviousValueKey = "previous_value"
+
+type clusterSettingPreviousValue struct {
+	Value      string `json:"value"`
+	Overridden bool   `json:"overridden"`
 }
 
 func (r *ClusterSettingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,8 +89,46 @@ func (r *ClusterSettingResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"setting_value": schema.StringAttribute{
-				MarkdownDescription: "Setting value",
-				Required:            true,
+				MarkdownDescription: `
+Setting value.
+Values are validated against the setting type at plan time and compared semantically, so ` + "`1h`" + ` and ` + "`01:00:00`" + ` or ` + "`64 MiB`" + ` and ` + "`67108864`" + ` are considered equal.
+`,
+				Required: true,
+			},
+			"virtual_cluster": schema.StringAttribute{
+				MarkdownDescription: `
+Name of the virtual cluster to apply the setting to, using ` + "`ALTER VIRTUAL CLUSTER ... SET CLUSTER SETTING`" + `.
+Use ` + "`all`" + ` to set the override shared by every virtual cluster (` + "`ALTER TENANT ALL`" + `).
+When omitted the setting of the system tenant the provider connects to is managed.
+`,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"setting_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the setting as reported by the cluster (bool, int, float, duration, byte_size, string, enum or version)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: `
+What to do with the setting when the resource is destroyed.
+` + "`reset`" + ` resets the setting to its default,
+` + "`restore_previous`" + ` restores the value the setting had before it was created or imported,
+` + "`keep`" + ` leaves the current value in place.
+`,
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(clusterSettingOnDestroyReset),
+				Validators: []validator.String{
+					stringvalidator.OneOf(clusterSettingOnDestroyReset, clusterSettingOnDestroyRestorePrevious, clusterSettingOnDestroyKeep),
+				},
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -66,6 +137,9 @@ func (r *ClusterSettingResource) Schema(ctx context.Context, req resource.Schema
 				Required:            false,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -85,48 +159,104 @@ func (r *ClusterSettingResource) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
-func (r *ClusterSettingResource) setClusterSetting(ctx context.Context, clusterId string, settingName string, settingValue string) error {
+func (r *ClusterSettingResource) setClusterSetting(ctx context.Context, clusterId string, virtualCluster string, settingName string, settingValue string) error {
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("SET CLUSTER SETTING %s = $1", pgx.Identifier{settingName}.Sanitize()), settingValue)
-		return nil, err
+		return nil, execSetVirtualClusterSetting(ctx, db, virtualCluster, settingName, settingValue)
 	})
 
 	return err
 }
 
+func (r *ClusterSettingResource) getClusterSettingInfo(ctx context.Context, clusterId string, virtualCluster string, settingName string) (*clusterSettingInfo, error) {
+	return ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*clusterSettingInfo, error) {
+		return getVirtualClusterSettingInfo(ctx, db, virtualCluster, settingName)
+	})
+}
+
+// ModifyPlan validates a new setting value against the setting type. Equivalent values are kept in their configured
+// form by Read, so the plan is never rewritten.
+func (r *ClusterSettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ClusterSettingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ClusterId.IsUnknown() || plan.SettingName.IsUnknown() || plan.SettingValue.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state ClusterSettingResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		// Only look the setting up when the value changes, the type is already in state
+		if resp.Diagnostics.HasError() || plan.SettingValue.Equal(state.SettingValue) {
+			return
+		}
+	}
+
+	// Types are the same across virtual clusters, the system tenant is enough for validation
+	info, err := r.getClusterSettingInfo(ctx, plan.ClusterId.ValueString(), "", plan.SettingName.ValueString())
+	if err != nil {
+		var notFound ClusterSettingNotFoundError
+		if errors.As(err, &notFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("setting_name"), "Unknown cluster setting", err.Error())
+			return
+		}
+		// The cluster may not exist yet, validation will happen when the setting is applied
+		tflog.Warn(ctx, fmt.Sprintf("Unable to look up cluster setting %s: %s", plan.SettingName.ValueString(), err.Error()))
+		return
+	}
+
+	if _, err := info.Normalize(plan.SettingValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("setting_value"), fmt.Sprintf("Invalid value for %s setting", info.TypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("setting_type"), types.StringValue(info.TypeName()))...)
+}
+
 func (r *ClusterSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ClusterSettingResourceModel
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Setting cluster setting %s with value %s for cluster %s", data.SettingName, data.SettingValue, data.ClusterId))
-	err := r.setClusterSetting(ctx, data.ClusterId.ValueString(), data.SettingName.ValueString(), data.SettingValue.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	previous, err := r.getClusterSettingInfo(ctx, data.ClusterId.ValueString(), data.VirtualCluster.ValueString(), data.SettingName.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to set cluster setting", err.Error())
+		resp.Diagnostics.AddError("Unable to get cluster setting", err.Error())
 		return
 	}
 
-	data.Id = types.StringValue(fmt.Sprintf("%s|%s", data.ClusterId.ValueString(), data.SettingName.ValueString()))
+	resp.Diagnostics.Append(setClusterSettingPreviousValue(ctx, resp.Private, previous)...)
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-func (r *ClusterSettingResource) getClusterSetting(ctx context.Context, clusterId string, settingName string) (*string, error) {
-	return ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*string, error) {
-		var value string
+	tflog.Debug(ctx, fmt.Sprintf("Setting cluster setting %s with value %s for cluster %s", data.SettingName, data.SettingValue, data.ClusterId))
+	err = r.setClusterSetting(ctx, data.ClusterId.ValueString(), data.VirtualCluster.ValueString(), data.SettingName.ValueString(), data.SettingValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to set cluster setting", err.Error())
+		return
+	}
 
-		// Funky query that casts the resulting type to a string
-		err := db.QueryRow(fmt.Sprintf("with x as (show cluster setting %s) select value::TEXT from x as t(value)", pgx.Identifier{settingName}.Sanitize())).Scan(&value)
+	data.Id = types.StringValue(buildClusterSettingId(data.ClusterId.ValueString(), data.VirtualCluster.ValueString(), data.SettingName.ValueString()))
+	data.SettingType = types.StringValue(previous.TypeName())
 
-		if err != nil {
-			return nil, err
-		}
-		return &value, nil
-	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ClusterSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -138,16 +268,24 @@ func (r *ClusterSettingResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	settingRow, err := r.getClusterSetting(ctx, data.ClusterId.ValueString(), data.SettingName.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	info, err := r.getClusterSettingInfo(ctx, data.ClusterId.ValueString(), data.VirtualCluster.ValueString(), data.SettingName.ValueString())
 	if err != nil && !errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) && !errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
 		resp.Diagnostics.AddError("Unable to get cluster setting", err.Error())
 		return
 	}
 
-	if settingRow == nil {
+	if info == nil || !info.IsSet() {
 		data.SettingValue = types.StringNull()
 	} else {
-		data.SettingValue = types.StringValue(*settingRow)
+		data.SettingType = types.StringValue(info.TypeName())
+
+		// Keep the configured representation as long as it is equivalent to the current value
+		if data.SettingValue.IsNull() || !info.Equivalent(data.SettingValue.ValueString(), info.Value) {
+			data.SettingValue = types.StringValue(info.Value)
+		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -162,7 +300,10 @@ func (r *ClusterSettingResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	err := r.setClusterSetting(ctx, data.ClusterId.ValueString(), data.SettingName.ValueString(), data.SettingValue.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	err := r.setClusterSetting(ctx, data.ClusterId.ValueString(), data.VirtualCluster.ValueString(), data.SettingName.ValueString(), data.SettingValue.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to set cluster setting", err.Error())
@@ -181,9 +322,42 @@ func (r *ClusterSettingResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	var previous *clusterSettingPreviousValue
+
+	switch data.OnDestroy.ValueString() {
+	case clusterSettingOnDestroyKeep:
+		tflog.Debug(ctx, fmt.Sprintf("Keeping cluster setting %s", data.SettingName.ValueString()))
+		return
+	case clusterSettingOnDestroyRestorePrevious:
+		previousBytes, diags := req.Private.GetKey(ctx, clusterSettingPreviousValueKey)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if previousBytes == nil {
+			resp.Diagnostics.AddWarning("No previous cluster setting value recorded",
+				fmt.Sprintf("The value of %s before it was managed by terraform is unknown, resetting it to its default instead", data.SettingName.ValueString()))
+			break
+		}
+
+		previous = &clusterSettingPreviousValue{}
+		if err := json.Unmarshal(previousBytes, previous); err != nil {
+			resp.Diagnostics.AddError("Unable to read previous cluster setting value", err.Error())
+			return
+		}
+	}
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("RESET CLUSTER SETTING %s", pgx.Identifier{data.SettingName.ValueString()}.Sanitize()))
-		return nil, err
+		if previous != nil && previous.Overridden {
+			tflog.Debug(ctx, fmt.Sprintf("Restoring cluster setting %s to %s", data.SettingName.ValueString(), previous.Value))
+			return nil, execSetVirtualClusterSetting(ctx, db, data.VirtualCluster.ValueString(), data.SettingName.ValueString(), previous.Value)
+		}
+		return nil, execResetVirtualClusterSetting(ctx, db, data.VirtualCluster.ValueString(), data.SettingName.ValueString())
 	})
 
 	if err != nil {
@@ -192,31 +366,66 @@ func (r *ClusterSettingResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setClusterSettingPreviousValue records the value of the setting before terraform took over so it can be restored on destroy.
+func setClusterSettingPreviousValue(ctx context.Context, private privateStateSetter, info *clusterSettingInfo) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	previousBytes, err := json.Marshal(clusterSettingPreviousValue{
+		Value:      info.Value,
+		Overridden: info.Overridden(),
+	})
+	if err != nil {
+		diags.AddError("Unable to record previous cluster setting value", err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, clusterSettingPreviousValueKey, previousBytes)
+}
+
 func (r *ClusterSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var data ClusterSettingResourceModel
 
 	idParts := strings.Split(req.ID, "|")
-	if len(idParts) != 2 {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format <cluster_id>|<setting_name>, got: %s", req.ID))
+	if len(idParts) != 2 && len(idParts) != 3 {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format <cluster_id>|<setting_name> or <cluster_id>|<virtual_cluster>|<setting_name>, got: %s", req.ID))
 		return
 	}
 	clusterId := idParts[0]
-	settingName := idParts[1]
+	settingName := idParts[len(idParts)-1]
+	virtualCluster := types.StringNull()
+	if len(idParts) == 3 {
+		virtualCluster = types.StringValue(idParts[1])
+	}
 
-	settingRow, err := r.getClusterSetting(ctx, clusterId, settingName)
+	info, err := r.getClusterSettingInfo(ctx, clusterId, virtualCluster.ValueString(), settingName)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get cluster setting", err.Error())
 		return
 	}
 
+	if !info.IsSet() {
+		resp.Diagnostics.AddError("Unable to get cluster setting", fmt.Sprintf("%s is not set for all virtual clusters", settingName))
+		return
+	}
+
 	data = ClusterSettingResourceModel{
-		ClusterId:    types.StringValue(clusterId),
-		SettingName:  types.StringValue(settingName),
-		SettingValue: types.StringValue(*settingRow),
-		Id:           types.StringValue(req.ID),
+		ClusterId:      types.StringValue(clusterId),
+		SettingName:    types.StringValue(settingName),
+		SettingValue:   types.StringValue(info.Value),
+		SettingType:    types.StringValue(info.TypeName()),
+		VirtualCluster: virtualCluster,
+		OnDestroy:      types.StringValue(clusterSettingOnDestroyReset),
+		Id:             types.StringValue(req.ID),
+		Timeouts:       nullTimeouts(),
 	}
 
+	// The value at import time is the one operators tuned manually, restore_previous brings it back
+	resp.Diagnostics.Append(setClusterSettingPreviousValue(ctx, resp.Private, info)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,404 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx"
+)
+
+// Setting type codes as reported by the setting_type column of SHOW ALL CLUSTER SETTINGS.
+const (
+	clusterSettingTypeBool     = "b"
+	clusterSettingTypeInt      = "i"
+	clusterSettingTypeFloat    = "f"
+	clusterSettingTypeDuration = "d"
+	clusterSettingTypeByteSize = "z"
+	clusterSettingTypeString   = "s"
+	clusterSettingTypeEnum     = "e"
+	clusterSettingTypeVersion  = "m"
+)
+
+var clusterSettingTypeNames = map[string]string{
+	clusterSettingTypeBool:     "bool",
+	clusterSettingTypeInt:      "int",
+	clusterSettingTypeFloat:    "float",
+	clusterSettingTypeDuration: "duration",
+	clusterSettingTypeByteSize: "byte_size",
+	clusterSettingTypeString:   "string",
+	clusterSettingTypeEnum:     "enum",
+	clusterSettingTypeVersion:  "version",
+}
+
+type ClusterSettingNotFoundError struct {
+	Name string
+}
+
+func (e ClusterSettingNotFoundError) Error() string {
+	return fmt.Sprintf("unknown cluster setting %s", e.Name)
+}
+
+type clusterSettingInfo struct {
+	Name        string
+	Value       string
+	Type        string
+	Description string
+	Origin      string
+
+	allVirtualClusters bool
+}
+
+// allVirtualClusters is the virtual_cluster value that targets the override shared by every virtual cluster (ALTER TENANT ALL).
+const allVirtualClusters = "all"
+
+func isAllVirtualClusters(virtualCluster string) bool {
+	return strings.EqualFold(virtualCluster, allVirtualClusters)
+}
+
+// clusterSettingAlterPrefix returns the statement prefix targeting the settings of virtualCluster, or the system tenant when empty.
+func clusterSettingAlterPrefix(virtualCluster string) string {
+	switch {
+	case virtualCluster == "":
+		return ""
+	case isAllVirtualClusters(virtualCluster):
+		return "ALTER TENANT ALL "
+	default:
+		return fmt.Sprintf("ALTER VIRTUAL CLUSTER %s ", pgx.Identifier{virtualCluster}.Sanitize())
+	}
+}
+
+func (i *clusterSettingInfo) TypeName() string {
+	if name, ok := clusterSettingTypeNames[i.Type]; ok {
+		return name
+	}
+	return i.Type
+}
+
+// Normalize converts value into the canonical form for the setting type so that equivalent values compare equal.
+func (i *clusterSettingInfo) Normalize(value string) (string, error) {
+	return normalizeClusterSettingValue(i.Type, i.Description, value)
+}
+
+// Equivalent reports whether two values are semantically equal for the setting type.
+func (i *clusterSettingInfo) Equivalent(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	normalizedA, err := i.Normalize(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := i.Normalize(b)
+	if err != nil {
+		return false
+	}
+	return normalizedA == normalizedB
+}
+
+// IsSet reports whether the setting has a value, the override shared by all virtual clusters may be missing.
+func (i *clusterSettingInfo) IsSet() bool {
+	return !i.allVirtualClusters || i.Overridden()
+}
+
+// Overridden reports whether the setting was explicitly set rather than left at its default.
+// For virtual clusters only overrides specific to that virtual cluster count.
+func (i *clusterSettingInfo) Overridden() bool {
+	switch i.Origin {
+	case "override", "per-tenant-override":
+		return true
+	case "all-tenants-override":
+		return i.allVirtualClusters
+	}
+	return false
+}
+
+func getClusterSettingInfo(ctx context.Context, db *pgx.ConnPool, settingName string) (*clusterSettingInfo, error) {
+	info := clusterSettingInfo{}
+	err := db.QueryRowEx(ctx, "SELECT variable, value, setting_type, description, origin FROM [SHOW ALL CLUSTER SETTINGS] WHERE variable = $1", nil, settingName).
+		Scan(&info.Name, &info.Value, &info.Type, &info.Description, &info.Origin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ClusterSettingNotFoundError{Name: settingName}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func getAllClusterSettingInfo(ctx context.Context, db *pgx.ConnPool) (map[string]*clusterSettingInfo, error) {
+	rows, err := db.QueryEx(ctx, "SELECT variable, value, setting_type, description, origin FROM [SHOW ALL CLUSTER SETTINGS]", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := map[string]*clusterSettingInfo{}
+	for rows.Next() {
+		info := clusterSettingInfo{}
+		if err := rows.Scan(&info.Name, &info.Value, &info.Type, &info.Description, &info.Origin); err != nil {
+			return nil, err
+		}
+		settings[info.Name] = &info
+	}
+	return settings, rows.Err()
+}
+
+// getOverriddenClusterSettings returns the names of all settings that were explicitly set with SET CLUSTER SETTING.
+func getOverriddenClusterSettings(ctx context.Context, db *pgx.ConnPool) ([]string, error) {
+	rows, err := db.QueryEx(ctx, "SELECT variable FROM [SHOW ALL CLUSTER SETTINGS] WHERE origin = 'override'", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// getVirtualClusterSettingInfo looks up a setting as seen by virtualCluster. The type and description always come from the system tenant.
+func getVirtualClusterSettingInfo(ctx context.Context, db *pgx.ConnPool, virtualCluster string, settingName string) (*clusterSettingInfo, error) {
+	info, err := getClusterSettingInfo(ctx, db, settingName)
+	if err != nil || virtualCluster == "" {
+		return info, err
+	}
+
+	if isAllVirtualClusters(virtualCluster) {
+		// There is no SHOW statement for the overrides shared by all virtual clusters
+		info.allVirtualClusters = true
+		err = db.QueryRowEx(ctx, "SELECT value FROM system.tenant_settings WHERE tenant_id = 0 AND name = $1", nil, settingName).Scan(&info.Value)
+		if errors.Is(err, pgx.ErrNoRows) {
+			info.Value = ""
+			info.Origin = "no-override"
+			return info, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		info.Origin = "all-tenants-override"
+		return info, nil
+	}
+
+	err = db.QueryRowEx(ctx, fmt.Sprintf("SELECT value, origin FROM [SHOW ALL CLUSTER SETTINGS FOR VIRTUAL CLUSTER %s] WHERE variable = $1", pgx.Identifier{virtualCluster}.Sanitize()), nil, settingName).
+		Scan(&info.Value, &info.Origin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ClusterSettingNotFoundError{Name: settingName}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func execSetClusterSetting(ctx context.Context, db *pgx.ConnPool, settingName string, settingValue string) error {
+	return execSetVirtualClusterSetting(ctx, db, "", settingName, settingValue)
+}
+
+func execResetClusterSetting(ctx context.Context, db *pgx.ConnPool, settingName string) error {
+	return execResetVirtualClusterSetting(ctx, db, "", settingName)
+}
+
+func execSetVirtualClusterSetting(ctx context.Context, db *pgx.ConnPool, virtualCluster string, settingName string, settingValue string) error {
+	_, err := db.ExecEx(ctx, fmt.Sprintf("%sSET CLUSTER SETTING %s = $1", clusterSettingAlterPrefix(virtualCluster), pgx.Identifier{settingName}.Sanitize()), nil, unquoteSettingValue(settingValue))
+	return err
+}
+
+func execResetVirtualClusterSetting(ctx context.Context, db *pgx.ConnPool, virtualCluster string, settingName string) error {
+	_, err := db.ExecEx(ctx, fmt.Sprintf("%sRESET CLUSTER SETTING %s", clusterSettingAlterPrefix(virtualCluster), pgx.Identifier{settingName}.Sanitize()), nil)
+	return err
+}
+
+// unquoteSettingValue strips the quotes of a value written as a SQL string literal, like 'true'.
+func unquoteSettingValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+func normalizeClusterSettingValue(settingType string, description string, value string) (string, error) {
+	value = unquoteSettingValue(value)
+
+	switch settingType {
+	case clusterSettingTypeBool:
+		b, err := parseSettingBool(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	case clusterSettingTypeInt:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid integer value %q", value)
+		}
+		return strconv.FormatInt(i, 10), nil
+	case clusterSettingTypeFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid float value %q", value)
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), nil
+	case clusterSettingTypeDuration:
+		d, err := parseSettingDuration(value)
+		if err != nil {
+			return "", err
+		}
+		return d.String(), nil
+	case clusterSettingTypeByteSize:
+		b, err := parseSettingByteSize(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(b, 10), nil
+	case clusterSettingTypeEnum:
+		return normalizeSettingEnum(description, value)
+	default:
+		return value, nil
+	}
+}
+
+func parseSettingBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "t", "on", "yes", "1":
+		return true, nil
+	case "false", "f", "off", "no", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean value %q", value)
+}
+
+var intervalPattern = regexp.MustCompile(`^(?:(-?\d+) days? ?)?(?:(-)?(\d+):(\d{2}):(\d{2}(?:\.\d+)?))?$`)
+
+// parseSettingDuration accepts both Go style durations (1h30m) and the interval text output of CockroachDB (1 day 01:30:00).
+func parseSettingDuration(value string) (time.Duration, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+
+	matches := intervalPattern.FindStringSubmatch(value)
+	if matches == nil || value == "" {
+		return 0, fmt.Errorf("invalid duration value %q", value)
+	}
+
+	var d time.Duration
+	if matches[1] != "" {
+		days, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration value %q", value)
+		}
+		d += time.Duration(days) * 24 * time.Hour
+	}
+	if matches[3] != "" {
+		hours, _ := strconv.ParseInt(matches[3], 10, 64)
+		minutes, _ := strconv.ParseInt(matches[4], 10, 64)
+		seconds, err := strconv.ParseFloat(matches[5], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration value %q", value)
+		}
+		clock := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(math.Round(seconds*float64(time.Second)))
+		if matches[2] == "-" {
+			clock = -clock
+		}
+		d += clock
+	}
+	return d, nil
+}
+
+var byteSizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]*)$`)
+
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"ki":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1e6,
+	"mb":  1e6,
+	"mi":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1e9,
+	"gb":  1e9,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+	"p":   1e15,
+	"pb":  1e15,
+	"pi":  1 << 50,
+	"pib": 1 << 50,
+}
+
+// parseSettingByteSize accepts plain byte counts as well as human readable sizes like 64 MiB.
+func parseSettingByteSize(value string) (int64, error) {
+	matches := byteSizePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid byte size value %q", value)
+	}
+	multiplier, ok := byteSizeUnits[strings.ToLower(matches[2])]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size unit %q", matches[2])
+	}
+	size, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size value %q", value)
+	}
+	return int64(size * multiplier), nil
+}
+
+var enumValuesPattern = regexp.MustCompile(`\[([^\[\]]*=[^\[\]]*)\]\s*$`)
+
+// parseSettingEnumValues extracts the enum values from a setting description ending in [name = 0, other = 1].
+func parseSettingEnumValues(description string) map[string]string {
+	matches := enumValuesPattern.FindStringSubmatch(description)
+	if matches == nil {
+		return nil
+	}
+	values := map[string]string{}
+	for _, entry := range strings.Split(matches[1], ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		values[strings.TrimSpace(parts[1])] = strings.ToLower(strings.TrimSpace(parts[0]))
+	}
+	return values
+}
+
+func normalizeSettingEnum(description string, value string) (string, error) {
+	values := parseSettingEnumValues(description)
+	normalized := strings.ToLower(value)
+	if values == nil {
+		return normalized, nil
+	}
+	if name, ok := values[normalized]; ok {
+		return name, nil
+	}
+	for _, name := range values {
+		if name == normalized {
+			return name, nil
+		}
+	}
+	names := make([]string, 0, len(values))
+	for _, name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return "", fmt.Errorf("invalid enum value %q, expected one of: %s", value, strings.Join(names, ", "))
+}
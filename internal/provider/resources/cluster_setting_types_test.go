@@ -0,0 +1,104 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestNormalizeClusterSettingValue(t *testing.T) {
+	enumDescription := "default isolation level [read_committed = 1, serializable = 3]"
+
+	tests := []struct {
+		name        string
+		settingType string
+		description string
+		value       string
+		expected    string
+	}{
+		{"bool", clusterSettingTypeBool, "", "true", "true"},
+		{"bool quoted", clusterSettingTypeBool, "", "'true'", "true"},
+		{"bool alias", clusterSettingTypeBool, "", "on", "true"},
+		{"bool false", clusterSettingTypeBool, "", "F", "false"},
+		{"int", clusterSettingTypeInt, "", " 42 ", "42"},
+		{"float", clusterSettingTypeFloat, "", "0.50", "0.5"},
+		{"duration go", clusterSettingTypeDuration, "", "1h", "1h0m0s"},
+		{"duration interval", clusterSettingTypeDuration, "", "01:00:00", "1h0m0s"},
+		{"duration days", clusterSettingTypeDuration, "", "1 day 01:30:00", "25h30m0s"},
+		{"duration negative", clusterSettingTypeDuration, "", "-00:00:01.5", "-1.5s"},
+		{"byte size unit", clusterSettingTypeByteSize, "", "64 MiB", "67108864"},
+		{"byte size plain", clusterSettingTypeByteSize, "", "67108864", "67108864"},
+		{"byte size decimal unit", clusterSettingTypeByteSize, "", "1.5KB", "1500"},
+		{"enum name", clusterSettingTypeEnum, enumDescription, "Serializable", "serializable"},
+		{"enum number", clusterSettingTypeEnum, enumDescription, "1", "read_committed"},
+		{"enum without values", clusterSettingTypeEnum, "no values", "Anything", "anything"},
+		{"string", clusterSettingTypeString, "", "  text ", "text"},
+		{"string quoted", clusterSettingTypeString, "", "'it''s'", "it's"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := normalizeClusterSettingValue(test.settingType, test.description, test.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNormalizeClusterSettingValueInvalid(t *testing.T) {
+	enumDescription := "default isolation level [read_committed = 1, serializable = 3]"
+
+	tests := []struct {
+		name        string
+		settingType string
+		description string
+		value       string
+	}{
+		{"bool", clusterSettingTypeBool, "", "maybe"},
+		{"int", clusterSettingTypeInt, "", "1.5"},
+		{"float", clusterSettingTypeFloat, "", "half"},
+		{"duration", clusterSettingTypeDuration, "", "an hour"},
+		{"duration empty", clusterSettingTypeDuration, "", ""},
+		{"byte size", clusterSettingTypeByteSize, "", "lots"},
+		{"byte size unit", clusterSettingTypeByteSize, "", "64 XB"},
+		{"enum", clusterSettingTypeEnum, enumDescription, "snapshot"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual, err := normalizeClusterSettingValue(test.settingType, test.description, test.value); err == nil {
+				t.Errorf("expected an error, got %q", actual)
+			}
+		})
+	}
+}
+
+func TestClusterSettingInfoEquivalent(t *testing.T) {
+	tests := []struct {
+		name        string
+		settingType string
+		a           string
+		b           string
+		expected    bool
+	}{
+		{"duration", clusterSettingTypeDuration, "1h", "01:00:00", true},
+		{"duration different", clusterSettingTypeDuration, "1h", "00:30:00", false},
+		{"byte size", clusterSettingTypeByteSize, "64 MiB", "67108864", true},
+		{"byte size different", clusterSettingTypeByteSize, "64 MB", "67108864", false},
+		{"bool", clusterSettingTypeBool, "true", "'true'", true},
+		{"bool different", clusterSettingTypeBool, "true", "false", false},
+		{"invalid", clusterSettingTypeBool, "maybe", "true", false},
+		{"identical invalid", clusterSettingTypeBool, "maybe", "maybe", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := clusterSettingInfo{Type: test.settingType}
+			if actual := info.Equivalent(test.a, test.b); actual != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,489 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &ClusterSettingsResource{}
+var _ resource.ResourceWithImportState = &ClusterSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterSettingsResource{}
+
+// Settings that are managed by Cockroach Cloud and are never reset in authoritative mode.
+var systemManagedClusterSettings = []string{
+	"version",
+	"cluster.organization",
+	"cluster.secret",
+	"enterprise.license",
+}
+
+func NewClusterSettingsResource() resource.Resource {
+	return &ClusterSettingsResource{}
+}
+
+type ClusterSettingsResource struct {
+	client *ccloud.CcloudClient
+}
+
+type ClusterSettingsResourceModel struct {
+	ClusterId      types.String   `tfsdk:"cluster_id"`
+	Settings       types.Map      `tfsdk:"settings"`
+	Authoritative  types.Bool     `tfsdk:"authoritative"`
+	IgnoreSettings types.Set      `tfsdk:"ignore_settings"`
+	Id             types.String   `tfsdk:"id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func buildClusterSettingsId(clusterId string) string {
+	return "cluster_settings|" + clusterId
+}
+
+func parseClusterSettingsId(id string) (clusterId string, err error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != 2 || parts[0] != "cluster_settings" {
+		return "", fmt.Errorf("expected ID to be in the format cluster_settings|<cluster_id>, got: %s", id)
+	}
+	return parts[1], nil
+}
+
+func (r *ClusterSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_settings"
+}
+
+func (r *ClusterSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Manage multiple cluster settings in a single resource.
+Settings removed from the map are reset to their default value.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Map of setting name to setting value. Values are validated and compared by setting type like `cockroach-extra_cluster_setting`.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: `
+When true, every overridden setting that is not in ` + "`settings`" + ` is reset to its default.
+Settings managed by Cockroach Cloud (` + "`version`, `cluster.organization`, `cluster.secret`, `enterprise.license`" + `) and ` + "`ignore_settings`" + ` are left alone.
+`,
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ignore_settings": schema.SetAttribute{
+				MarkdownDescription: "Settings that are never reset in authoritative mode",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Cluster settings ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ClusterSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (data *ClusterSettingsResourceModel) settingsMap(ctx context.Context) (map[string]string, error) {
+	settings := map[string]string{}
+	if diags := data.Settings.ElementsAs(ctx, &settings, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read settings")
+	}
+	return settings, nil
+}
+
+func (data *ClusterSettingsResourceModel) ignoredSettings(ctx context.Context) ([]string, error) {
+	ignored := slices.Clone(systemManagedClusterSettings)
+	var configured []string
+	if diags := data.IgnoreSettings.ElementsAs(ctx, &configured, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read ignore_settings")
+	}
+	return append(ignored, configured...), nil
+}
+
+func settingsToMapValue(settings map[string]string) types.Map {
+	values := map[string]attr.Value{}
+	for name, value := range settings {
+		values[name] = types.StringValue(value)
+	}
+	mapValue, _ := types.MapValue(types.StringType, values)
+	return mapValue
+}
+
+// unmanagedOverrides returns overridden settings that are neither in the managed set nor ignored.
+func unmanagedOverrides(ctx context.Context, db *pgx.ConnPool, managed map[string]string, ignored []string) ([]string, error) {
+	overridden, err := getOverriddenClusterSettings(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var unmanaged []string
+	for _, name := range overridden {
+		if _, ok := managed[name]; ok || slices.Contains(ignored, name) {
+			continue
+		}
+		unmanaged = append(unmanaged, name)
+	}
+	return unmanaged, nil
+}
+
+// applyClusterSettings sets every setting in desired that differs from previous and resets every setting in previous missing from desired.
+func applyClusterSettings(ctx context.Context, db *pgx.ConnPool, desired map[string]string, previous map[string]string) error {
+	for name, value := range desired {
+		if previousValue, ok := previous[name]; ok && previousValue == value {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Setting cluster setting %s to %s", name, value))
+		if err := execSetClusterSetting(ctx, db, name, value); err != nil {
+			return fmt.Errorf("unable to set %s: %w", name, err)
+		}
+	}
+	for name := range previous {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Resetting cluster setting %s", name))
+		if err := execResetClusterSetting(ctx, db, name); err != nil {
+			return fmt.Errorf("unable to reset %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ModifyPlan validates every changed value against its setting type.
+func (r *ClusterSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ClusterSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ClusterId.IsUnknown() || plan.Settings.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state ClusterSettingsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		// Only look the settings up when they change, Read keeps the configured spelling of equivalent values
+		if resp.Diagnostics.HasError() || plan.Settings.Equal(state.Settings) {
+			return
+		}
+	}
+
+	settings, err := plan.settingsMap(ctx)
+	if err != nil {
+		// Individual values may still be unknown
+		return
+	}
+
+	infos, err := ccloud.SqlConWithTempUser(ctx, r.client, plan.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*map[string]*clusterSettingInfo, error) {
+		infos, err := getAllClusterSettingInfo(ctx, db)
+		return &infos, err
+	})
+	if err != nil {
+		// The cluster may not exist yet, validation will happen when the settings are applied
+		tflog.Warn(ctx, fmt.Sprintf("Unable to look up cluster settings: %s", err.Error()))
+		return
+	}
+
+	for name, value := range settings {
+		info, ok := (*infos)[name]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtMapKey(name), "Unknown cluster setting", ClusterSettingNotFoundError{Name: name}.Error())
+			continue
+		}
+		if _, err := info.Normalize(value); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtMapKey(name), fmt.Sprintf("Invalid value for %s setting", info.TypeName()), err.Error())
+		}
+	}
+
+}
+
+func (r *ClusterSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	settings, err := data.settingsMap(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	ignored, err := data.ignoredSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		previous := map[string]string{}
+		if data.Authoritative.ValueBool() {
+			unmanaged, err := unmanagedOverrides(ctx, db, settings, ignored)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range unmanaged {
+				previous[name] = ""
+			}
+		}
+		return nil, applyClusterSettings(ctx, db, settings, previous)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to set cluster settings", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildClusterSettingsId(data.ClusterId.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readSettings returns the current value of every managed setting, plus every unmanaged override when authoritative.
+func (r *ClusterSettingsResource) readSettings(ctx context.Context, data *ClusterSettingsResourceModel) (map[string]string, error) {
+	settings, err := data.settingsMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ignored, err := data.ignoredSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*map[string]string, error) {
+		infos, err := getAllClusterSettingInfo(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+
+		current := map[string]string{}
+		for name, value := range settings {
+			info, ok := infos[name]
+			if !ok {
+				return nil, ClusterSettingNotFoundError{Name: name}
+			}
+			// Keep the configured representation as long as it is equivalent to the current value
+			if info.Equivalent(value, info.Value) {
+				current[name] = value
+			} else {
+				current[name] = info.Value
+			}
+		}
+
+		if data.Authoritative.ValueBool() {
+			unmanaged, err := unmanagedOverrides(ctx, db, settings, ignored)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range unmanaged {
+				current[name] = infos[name].Value
+			}
+		}
+
+		return &current, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return *current, nil
+}
+
+func (r *ClusterSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	settings, err := r.readSettings(ctx, &data)
+
+	if err != nil {
+		if errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	data.Settings = settingsToMapValue(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ClusterSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	settings, err := plan.settingsMap(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	previous, err := state.settingsMap(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	ignored, err := plan.ignoredSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, plan.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		if plan.Authoritative.ValueBool() && !state.Authoritative.ValueBool() {
+			unmanaged, err := unmanagedOverrides(ctx, db, settings, ignored)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range unmanaged {
+				if _, ok := previous[name]; !ok {
+					previous[name] = ""
+				}
+			}
+		}
+		return nil, applyClusterSettings(ctx, db, settings, previous)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update cluster settings", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	settings, err := data.settingsMap(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		return nil, applyClusterSettings(ctx, db, map[string]string{}, settings)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to reset cluster settings", err.Error())
+		return
+	}
+}
+
+func (r *ClusterSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterId, err := parseClusterSettingsId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", err.Error())
+		return
+	}
+
+	// Import every overridden setting, the configuration can then be trimmed down as needed
+	data := ClusterSettingsResourceModel{
+		ClusterId:      types.StringValue(clusterId),
+		Settings:       settingsToMapValue(map[string]string{}),
+		Authoritative:  types.BoolValue(true),
+		IgnoreSettings: types.SetNull(types.StringType),
+		Id:             types.StringValue(req.ID),
+		Timeouts:       nullTimeouts(),
+	}
+
+	settings, err := r.readSettings(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read cluster settings", err.Error())
+		return
+	}
+
+	data.Settings = settingsToMapValue(settings)
+	data.Authoritative = types.BoolValue(false)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
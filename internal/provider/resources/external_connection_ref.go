@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+// externalConnectionRefUri returns the external:// URI of an external connection resource ID.
+func externalConnectionRefUri(connectionId types.String) types.String {
+	if connectionId.IsNull() || connectionId.IsUnknown() {
+		return connectionId
+	}
+	_, connectionName, err := parseExternalConnectionId(connectionId.ValueString())
+	if err != nil {
+		return types.StringUnknown()
+	}
+	return types.StringValue(getExternalConnectionUri(connectionName))
+}
+
+// externalConnectionNameFromUri returns the connection name of an external:// URI.
+func externalConnectionNameFromUri(uri string) (string, bool) {
+	parsedUri, err := url.Parse(uri)
+	if err != nil || parsedUri.Scheme != "external" || parsedUri.Host == "" {
+		return "", false
+	}
+	return parsedUri.Host, true
+}
+
+// getExternalConnectionTargetUri returns the URI an external connection points to.
+func getExternalConnectionTargetUri(ctx context.Context, db *pgx.ConnPool, connectionName string) (string, error) {
+	var connectionStatement string
+	err := db.QueryRowEx(ctx, fmt.Sprintf("SHOW CREATE EXTERNAL CONNECTION %s", pgx.Identifier{connectionName}.Sanitize()), nil).Scan(nil, &connectionStatement)
+	if err != nil {
+		return "", err
+	}
+	_, connectionUri, err := parseCreateConnectionStatement(connectionStatement)
+	return connectionUri, err
+}
+
+// sinkUriMatches reports whether the sink URI of a job matches the configured one. Jobs may report the URI an
+// external connection points to instead of the external:// reference.
+func sinkUriMatches(ctx context.Context, client *ccloud.CcloudClient, clusterId string, configured string, current string) bool {
+	if CompareURLs(configured, current) {
+		return true
+	}
+	connectionName, ok := externalConnectionNameFromUri(configured)
+	if !ok {
+		return false
+	}
+	targetUri, err := ccloud.SqlConWithTempUser(ctx, client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*string, error) {
+		targetUri, err := getExternalConnectionTargetUri(ctx, db, connectionName)
+		return &targetUri, err
+	})
+	return err == nil && CompareURLs(*targetUri, current)
+}
+
+// validateExternalConnectionRef checks that the external connection exists on the cluster.
+func validateExternalConnectionRef(ctx context.Context, client *ccloud.CcloudClient, clusterId string, attributePath path.Path, connectionId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	connectionClusterId, connectionName, err := parseExternalConnectionId(connectionId)
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid external connection", err.Error())
+		return diags
+	}
+	if connectionClusterId != clusterId {
+		diags.AddAttributeError(attributePath, "Invalid external connection", fmt.Sprintf("External connection %s belongs to cluster %s, not %s", connectionName, connectionClusterId, clusterId))
+		return diags
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*string, error) {
+		targetUri, err := getExternalConnectionTargetUri(ctx, db, connectionName)
+		return &targetUri, err
+	})
+	var pgErr pgx.PgError
+	if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "42704") {
+		diags.AddAttributeError(attributePath, "External connection not found", fmt.Sprintf("External connection %s does not exist on cluster %s", connectionName, clusterId))
+	} else if err != nil {
+		diags.AddAttributeError(attributePath, "Unable to read external connection", err.Error())
+	}
+	return diags
+}
+
+type externalConnectionIdValidator struct{}
+
+func (v externalConnectionIdValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v externalConnectionIdValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be the ID of an external connection resource"
+}
+
+func (v externalConnectionIdValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	if _, _, err := parseExternalConnectionId(value.ValueString()); err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			value.String(),
+		))
+	}
+}
+
+func ExternalConnectionIdValidator() validator.String {
+	return externalConnectionIdValidator{}
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type jsonObjectValidator struct{}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v jsonObjectValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(value.ValueString()), &object); err != nil {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			value.String(),
+		))
+	}
+}
+
+func JsonObjectValidator() validator.String {
+	return jsonObjectValidator{}
+}
@@ -11,7 +11,9 @@ import (
 	_ "github.com/golang-migrate/migrate/source/file"
 	_ "github.com/golang-migrate/migrate/source/github"
 	_ "github.com/golang-migrate/migrate/source/google_cloud_storage"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -23,6 +25,7 @@ import (
 	"github.com/jackc/pgx/stdlib"
 	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
 	"os"
+	"strings"
 )
 
 type MigrationResource struct {
@@ -30,15 +33,17 @@ type MigrationResource struct {
 }
 
 type MigrationResourceModel struct {
-	ClusterId     types.String `tfsdk:"cluster_id"`
-	Database      types.String `tfsdk:"database"`
-	MigrationsUrl types.String `tfsdk:"migrations_url"`
-	DestroyMode   types.String `tfsdk:"destroy_mode"`
-	Version       types.Int64  `tfsdk:"version"`
-	Id            types.String `tfsdk:"id"`
+	ClusterId     types.String   `tfsdk:"cluster_id"`
+	Database      types.String   `tfsdk:"database"`
+	MigrationsUrl types.String   `tfsdk:"migrations_url"`
+	DestroyMode   types.String   `tfsdk:"destroy_mode"`
+	Version       types.Int64    `tfsdk:"version"`
+	Id            types.String   `tfsdk:"id"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 }
 
 var _ resource.Resource = &MigrationResource{}
+var _ resource.ResourceWithImportState = &MigrationResource{}
 
 func NewMigrationResource() resource.Resource {
 	return &MigrationResource{}
@@ -98,6 +103,9 @@ func (r *MigrationResource) Schema(ctx context.Context, req resource.SchemaReque
 				Required: false,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -144,10 +152,19 @@ func (r *MigrationResource) runMigrations(ctx context.Context, data *MigrationRe
 		}
 		migrator.Log = MigrationLogger{ctx: ctx}
 
+		// Stop after the running migration once the timeout expires
+		stop := context.AfterFunc(ctx, func() {
+			migrator.GracefulStop <- true
+		})
+		defer stop()
+
 		err = migrator.Migrate(uint(data.Version.ValueInt64()))
 		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
 			return nil, err
 		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("migrations were stopped: %w", err)
+		}
 
 		version, _, err := migrator.Version()
 
@@ -168,6 +185,9 @@ func (r *MigrationResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	version, err := r.runMigrations(ctx, &data)
 
 	if err != nil {
@@ -193,6 +213,9 @@ func (r *MigrationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	tempDir, err := os.MkdirTemp("", "migration_resource")
 
 	if err != nil {
@@ -247,6 +270,9 @@ func (r *MigrationResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
 	version, err := r.runMigrations(ctx, &data)
 
 	if err != nil {
@@ -268,6 +294,9 @@ func (r *MigrationResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	if data.DestroyMode.ValueString() == "noop" {
 		resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 		return
@@ -310,3 +339,16 @@ func (r *MigrationResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
+
+func (r *MigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// migrations_url and destroy_mode are not stored in the database, they are taken from the configuration on the next apply
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 3 || idParts[2] != "migrations" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format <cluster_id>|<database>|migrations, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database"), idParts[1])...)
+}
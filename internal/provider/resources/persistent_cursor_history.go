@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+// rewindToRegex matches a checkpoint ID, a CockroachDB HLC timestamp or an RFC 3339 timestamp.
+var rewindToRegex = regexp.MustCompile(`^(\d+|\d+\.\d+|\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}))$`)
+
+// recordCursorCheckpoint records the high-water timestamp of the last job that used the cursor and returns the ID
+// of the latest checkpoint, if any.
+func recordCursorCheckpoint(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef) (*int64, error) {
+	if err := ensureCursorTable(ctx, client, cursor); err != nil {
+		return nil, err
+	}
+
+	return ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (*int64, error) {
+		_, err := db.ExecEx(ctx, fmt.Sprintf(`
+INSERT INTO %s (key, job_id, high_water_timestamp)
+SELECT ct.key, ct.last_used_job_id, jobs.high_water_timestamp
+FROM %s ct
+JOIN [SHOW CHANGEFEED JOBS] AS jobs ON jobs.job_id = ct.last_used_job_id
+WHERE ct.key = $1 AND jobs.high_water_timestamp IS NOT NULL
+ON CONFLICT (key, job_id, high_water_timestamp) DO NOTHING
+`, cursor.table(persistentCursorHistoryTable), cursor.table(persistentCursorTable)), nil, cursor.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		var checkpointId int64
+		err = db.QueryRowEx(ctx, fmt.Sprintf("SELECT id FROM %s WHERE key = $1 ORDER BY recorded_at DESC, id DESC LIMIT 1", cursor.table(persistentCursorHistoryTable)), nil, cursor.Key).Scan(&checkpointId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &checkpointId, nil
+	})
+}
+
+// resolveRewindTo returns the HLC timestamp of a rewind_to value.
+func resolveRewindTo(ctx context.Context, db *pgx.ConnPool, cursor PersistentCursorRef, rewindTo string) (string, error) {
+	if strings.Contains(rewindTo, "T") {
+		timestamp, err := time.Parse(time.RFC3339Nano, rewindTo)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d.0000000000", timestamp.UnixNano()), nil
+	}
+	if strings.Contains(rewindTo, ".") {
+		return rewindTo, nil
+	}
+
+	checkpointId, err := strconv.ParseInt(rewindTo, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	var highWaterTimestamp string
+	err = db.QueryRowEx(ctx, fmt.Sprintf("SELECT high_water_timestamp::STRING FROM %s WHERE key = $1 AND id = $2", cursor.table(persistentCursorHistoryTable)), nil, cursor.Key, checkpointId).Scan(&highWaterTimestamp)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("checkpoint %d not found in the history of cursor %s", checkpointId, cursor.Key)
+	}
+	return highWaterTimestamp, err
+}
+
+// setCursorRewind makes the next changefeed created from the cursor start at rewindTo, nil drops a pending rewind.
+func setCursorRewind(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef, rewindTo *string) error {
+	if err := ensureCursorTable(ctx, client, cursor); err != nil {
+		return err
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		var timestamp *string
+		if rewindTo != nil {
+			resolved, err := resolveRewindTo(ctx, db, cursor, *rewindTo)
+			if err != nil {
+				return nil, err
+			}
+			timestamp = &resolved
+		}
+		_, err := db.ExecEx(ctx, fmt.Sprintf("UPDATE %s SET rewind_to = $1::DECIMAL WHERE key = $2", cursor.table(persistentCursorTable)), nil, timestamp, cursor.Key)
+		return nil, err
+	})
+	return err
+}
+
+// ClearCursorRewind forgets the pending rewind once a changefeed was created from it. A rewind set in the meantime
+// is kept.
+func ClearCursorRewind(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef, rewindTo string) error {
+	_, err := ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, fmt.Sprintf("UPDATE %s SET rewind_to = NULL WHERE key = $1 AND rewind_to = $2::DECIMAL", cursor.table(persistentCursorTable)), nil, cursor.Key, rewindTo)
+		return nil, err
+	})
+	return err
+}
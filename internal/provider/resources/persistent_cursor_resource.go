@@ -4,16 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jackc/pgx"
 	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
-	"strings"
 )
 
 var _ resource.Resource = &PersistentCursorResource{}
@@ -27,13 +30,18 @@ type PersistentCursorResource struct {
 }
 
 type PersistentCursorResourceModel struct {
-	ClusterId     types.String `tfsdk:"cluster_id"`
-	Key           types.String `tfsdk:"key"`
-	ResumeOffset  types.Int64  `tfsdk:"resume_offset"`
-	Id            types.String `tfsdk:"id"`
-	LastUsedJobId types.Int64  `tfsdk:"last_used_job_id"`
-	HighWaterMark types.String `tfsdk:"value"`
-	Ref           types.String `tfsdk:"ref"`
+	ClusterId        types.String   `tfsdk:"cluster_id"`
+	Key              types.String   `tfsdk:"key"`
+	Database         types.String   `tfsdk:"database"`
+	Schema           types.String   `tfsdk:"schema"`
+	ResumeOffset     types.Int64    `tfsdk:"resume_offset"`
+	Id               types.String   `tfsdk:"id"`
+	LastUsedJobId    types.Int64    `tfsdk:"last_used_job_id"`
+	HighWaterMark    types.String   `tfsdk:"value"`
+	Ref              types.String   `tfsdk:"ref"`
+	RewindTo         types.String   `tfsdk:"rewind_to"`
+	LastCheckpointId types.Int64    `tfsdk:"last_checkpoint_id"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *PersistentCursorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +54,12 @@ func (r *PersistentCursorResource) Schema(ctx context.Context, req resource.Sche
 Create a persistent cursor.
 This can be used with a changefeed to preserve the state of the cursor across restarts.
 If the cursor falls behind the gc window (job was in a failed state for too long), it will expire and changefeeds will not be able to resume from it.
+
+Cursors are stored in the ` + "`persistent_cursors`" + ` table of ` + "`database`" + `.` + "`schema`" + `, which the provider creates and migrates to its latest version.
+Only the provider can write to the cursor tables, other roles keep read access.
+
+The high-water timestamp of the last job is recorded as a checkpoint in ` + "`persistent_cursor_history`" + ` on every read and update.
+Set ` + "`rewind_to`" + ` to replay events from an earlier point, like after a consumer bug.
 `,
 		Attributes: map[string]schema.Attribute{
 			"cluster_id": schema.StringAttribute{
@@ -62,6 +76,24 @@ If the cursor falls behind the gc window (job was in a failed state for too long
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database of the cursor table, it must already exist",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultCursorDatabase),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "Schema of the cursor table, created if it does not exist",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultCursorSchema),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"resume_offset": schema.Int64Attribute{
 				MarkdownDescription: `
 Add an offset in seconds for changefeed resumption.
@@ -86,6 +118,21 @@ Useful for skipping over whatever caused the error.
 				Computed:            true,
 				MarkdownDescription: "Current timestamp of the cursor",
 			},
+			"rewind_to": schema.StringAttribute{
+				MarkdownDescription: `
+Start the next changefeed created from this cursor at this point instead of the high-water timestamp, ignoring ` + "`resume_offset`" + `.
+Accepts a checkpoint ID from the cursor history, a CockroachDB HLC timestamp like ` + "`1700000000000000000.0000000000`" + ` or an RFC 3339 timestamp.
+The rewind is used once, change the value to rewind again.
+`,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(rewindToRegex, "Rewind to must be a checkpoint ID, an HLC timestamp or an RFC 3339 timestamp"),
+				},
+			},
+			"last_checkpoint_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the latest checkpoint in the cursor history",
+			},
 			"ref": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Reference to the cursor",
@@ -94,28 +141,40 @@ Useful for skipping over whatever caused the error.
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 
 }
 
-const persistentCursorTable = "persistent_cursors"
+func (data *PersistentCursorResourceModel) cursorRef() PersistentCursorRef {
+	return PersistentCursorRef{
+		ClusterId: data.ClusterId.ValueString(),
+		Database:  data.Database.ValueString(),
+		Schema:    data.Schema.ValueString(),
+		Key:       data.Key.ValueString(),
+	}
+}
+
+// refreshCursor records a checkpoint and updates the computed attributes, it reports whether the cursor exists.
+func (r *PersistentCursorResource) refreshCursor(ctx context.Context, data *PersistentCursorResourceModel) (bool, error) {
+	checkpointId, err := recordCursorCheckpoint(ctx, r.client, data.cursorRef())
+	if err != nil {
+		return false, err
+	}
 
-func (r *PersistentCursorResource) ensureCursorTable(ctx context.Context, data *PersistentCursorResourceModel) error {
-	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		var count int
-		if err := db.QueryRow(fmt.Sprintf("SELECT count(*) FROM information_schema.tables WHERE table_name = '%s'", persistentCursorTable)).Scan(&count); err != nil {
-			return nil, err
-		}
-		if count == 1 {
-			return nil, nil
-		}
+	cursorValue, err := GetCursor(ctx, r.client, data.cursorRef())
+	if err != nil || !cursorValue.Exists {
+		return false, err
+	}
 
-		if _, err := db.Exec(fmt.Sprintf("CREATE TABLE %s (key STRING PRIMARY KEY, resume_offset INT, last_used_job_id INT)", persistentCursorTable)); err != nil {
-			return nil, err
-		}
-		return nil, nil
-	})
-	return err
+	data.ResumeOffset = types.Int64PointerValue(cursorValue.Offset)
+	data.LastUsedJobId = types.Int64PointerValue(cursorValue.LastJobId)
+	data.HighWaterMark = types.StringPointerValue(cursorValue.OffsetCursor)
+	data.LastCheckpointId = types.Int64PointerValue(checkpointId)
+	data.Ref = data.Id
+	return true, nil
 }
 
 type CursorValue struct {
@@ -126,23 +185,29 @@ type CursorValue struct {
 	LastJobId     *int64
 	LastJobStatus *string
 	InUse         bool
+	RewindTo      *string
 }
 
-func GetCursor(ctx context.Context, client *ccloud.CcloudClient, clusterId string, key string) (*CursorValue, error) {
-	return ccloud.SqlConWithTempUser(ctx, client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*CursorValue, error) {
-		var cursor, cursorOffset, lastJobStatus *string
+func GetCursor(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef) (*CursorValue, error) {
+	if err := ensureCursorTable(ctx, client, cursor); err != nil {
+		return nil, err
+	}
+
+	return ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (*CursorValue, error) {
+		var highWaterTimestamp, cursorOffset, lastJobStatus, rewindTo *string
 		var lastJobId, offset *int64
 		inUse := false
-		err := db.QueryRow(fmt.Sprintf(`
+		err := db.QueryRowEx(ctx, fmt.Sprintf(`
 SELECT high_water_timestamp::string as cursor,
        resume_offset,
-(high_water_timestamp::decimal + (resume_offset::decimal * 1000000))::string as offset_high_water_timestamp,
+coalesce(rewind_to, high_water_timestamp::decimal + (resume_offset::decimal * 1000000))::string as offset_high_water_timestamp,
 last_used_job_id last_used_job_id,
-jobs.status last_used_job_status
+jobs.status last_used_job_status,
+rewind_to::string
 from %s ct
 left outer join [show changefeed jobs] as jobs on jobs.job_id = ct.last_used_job_id
 where key = $1
-`, persistentCursorTable), key).Scan(&cursor, &offset, &cursorOffset, &lastJobId, &lastJobStatus)
+`, cursor.table(persistentCursorTable)), nil, cursor.Key).Scan(&highWaterTimestamp, &offset, &cursorOffset, &lastJobId, &lastJobStatus, &rewindTo)
 
 		if errors.Is(err, pgx.ErrNoRows) {
 			return &CursorValue{
@@ -159,20 +224,25 @@ where key = $1
 		}
 
 		return &CursorValue{
-			Cursor:        cursor,
+			Cursor:        highWaterTimestamp,
 			OffsetCursor:  cursorOffset,
 			Exists:        true,
 			Offset:        offset,
 			LastJobId:     lastJobId,
 			LastJobStatus: lastJobStatus,
 			InUse:         inUse,
+			RewindTo:      rewindTo,
 		}, nil
 	})
 }
 
-func UpdateCursorJobId(ctx context.Context, client *ccloud.CcloudClient, clusterId string, key string, jobId *int64) error {
-	_, err := ccloud.SqlConWithTempUser(ctx, client, clusterId, "defaultdb", func(db *pgx.ConnPool) (_ *interface{}, err error) {
-		tx, err := db.Begin()
+func UpdateCursorJobId(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef, jobId *int64) error {
+	if err := ensureCursorTable(ctx, client, cursor); err != nil {
+		return err
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (_ *interface{}, err error) {
+		tx, err := db.BeginEx(ctx, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -188,7 +258,7 @@ func UpdateCursorJobId(ctx context.Context, client *ccloud.CcloudClient, cluster
 				err = r
 			}
 		}()
-		err = tx.QueryRow(fmt.Sprintf("select key, last_used_job_id, (select status from [show changefeed jobs] where job_id = last_used_job_id) from %s where key =$1 for update", persistentCursorTable), key).Scan(
+		err = tx.QueryRowEx(ctx, fmt.Sprintf("select key, last_used_job_id, (select status from [show changefeed jobs] where job_id = last_used_job_id) from %s where key =$1 for update", cursor.table(persistentCursorTable)), nil, cursor.Key).Scan(
 			&returnedKey, &currentJobId, &status)
 
 		if err != nil {
@@ -201,7 +271,7 @@ func UpdateCursorJobId(ctx context.Context, client *ccloud.CcloudClient, cluster
 
 		// check if there are any other cursors that are in use by the same job
 		var otherCursorCount int
-		err = tx.QueryRow(fmt.Sprintf("select count(*) from %s where last_used_job_id = $1 and key != $2", persistentCursorTable), jobId, key).Scan(&otherCursorCount)
+		err = tx.QueryRowEx(ctx, fmt.Sprintf("select count(*) from %s where last_used_job_id = $1 and key != $2", cursor.table(persistentCursorTable)), nil, jobId, cursor.Key).Scan(&otherCursorCount)
 		if err != nil {
 			return nil, err
 		}
@@ -210,24 +280,16 @@ func UpdateCursorJobId(ctx context.Context, client *ccloud.CcloudClient, cluster
 			return nil, fmt.Errorf("Job cannot use multiple cursors")
 		}
 
-		_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET last_used_job_id = $1 WHERE key = $2", persistentCursorTable), jobId, key)
+		_, err = tx.ExecEx(ctx, fmt.Sprintf("UPDATE %s SET last_used_job_id = $1 WHERE key = $2", cursor.table(persistentCursorTable)), nil, jobId, cursor.Key)
 
 		if err != nil {
 			return nil, err
 		}
-		return nil, tx.Commit()
+		return nil, tx.CommitEx(ctx)
 	})
 	return err
 }
 
-func ParseCursorId(cursorId string) (clusterId, key string, err error) {
-	parts := strings.Split(cursorId, "|")
-	if len(parts) != 3 || parts[0] != "cursor" {
-		return "", "", fmt.Errorf("unable to parse cursor ID %s", cursorId)
-	}
-	return parts[1], parts[2], nil
-}
-
 func (r *PersistentCursorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -253,16 +315,22 @@ func (r *PersistentCursorResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	if data.ResumeOffset.IsNull() {
 		data.ResumeOffset = types.Int64Value(0)
 	}
 
-	if err := r.ensureCursorTable(ctx, &data); err != nil {
+	cursor := data.cursorRef()
+
+	if err := ensureCursorTable(ctx, r.client, cursor); err != nil {
 		resp.Diagnostics.AddError("Unable to create persistent cursor table", err.Error())
+		return
 	}
 
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("INSERT INTO %s (key, resume_offset) VALUES ($1, $2)", persistentCursorTable), data.Key.ValueString(), data.ResumeOffset.ValueInt64())
+		_, err := db.ExecEx(ctx, fmt.Sprintf("INSERT INTO %s (key, resume_offset) VALUES ($1, $2)", cursor.table(persistentCursorTable)), nil, data.Key.ValueString(), data.ResumeOffset.ValueInt64())
 		return nil, err
 	})
 
@@ -271,10 +339,19 @@ func (r *PersistentCursorResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	data.Id = types.StringValue(fmt.Sprintf("cursor|%s|%s", data.ClusterId.ValueString(), data.Key.ValueString()))
-	data.Ref = data.Id
-	data.HighWaterMark = types.StringNull()
-	data.LastUsedJobId = types.Int64Null()
+	data.Id = types.StringValue(cursor.Id())
+
+	if !data.RewindTo.IsNull() {
+		if err := setCursorRewind(ctx, r.client, cursor, data.RewindTo.ValueStringPointer()); err != nil {
+			resp.Diagnostics.AddError("Unable to rewind persistent cursor", err.Error())
+			return
+		}
+	}
+
+	if _, err := r.refreshCursor(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to read persistent cursor", err.Error())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -288,43 +365,56 @@ func (r *PersistentCursorResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	cursorValue, err := GetCursor(ctx, r.client, data.ClusterId.ValueString(), data.Key.ValueString())
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	// Cursors created before the location was configurable live in the default location
+	if data.Database.IsNull() {
+		data.Database = types.StringValue(defaultCursorDatabase)
+	}
+	if data.Schema.IsNull() {
+		data.Schema = types.StringValue(defaultCursorSchema)
+	}
+
+	exists, err := r.refreshCursor(ctx, &data)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to read persistent cursor", err.Error())
 		return
 	}
 
-	if !cursorValue.Exists {
+	if !exists {
 		//resp.Diagnostics.AddError("Persistent cursor not found", fmt.Sprintf("Cursor with key %s not found", data.Key.ValueString()))
 		resp.State.RemoveResource(ctx)
 		return
 	}
-	data.ResumeOffset = types.Int64Value(*cursorValue.Offset)
-
-	if cursorValue.LastJobId != nil {
-		data.LastUsedJobId = types.Int64Value(*cursorValue.LastJobId)
-	}
-	if cursorValue.OffsetCursor != nil {
-		data.HighWaterMark = types.StringValue(*cursorValue.OffsetCursor)
-	}
-
-	data.Ref = data.Id
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *PersistentCursorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data PersistentCursorResourceModel
+	var state PersistentCursorResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	cursor := data.cursorRef()
+
+	if err := ensureCursorTable(ctx, r.client, cursor); err != nil {
+		resp.Diagnostics.AddError("Unable to migrate persistent cursor table", err.Error())
+		return
+	}
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("UPDATE %s SET resume_offset = $1 WHERE key = $2", persistentCursorTable), data.ResumeOffset.ValueInt64(), data.Key.ValueString())
+		_, err := db.ExecEx(ctx, fmt.Sprintf("UPDATE %s SET resume_offset = $1 WHERE key = $2", cursor.table(persistentCursorTable)), nil, data.ResumeOffset.ValueInt64(), data.Key.ValueString())
 		return nil, err
 	})
 
@@ -333,7 +423,17 @@ func (r *PersistentCursorResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	data.Ref = data.Id
+	if !data.RewindTo.Equal(state.RewindTo) {
+		if err := setCursorRewind(ctx, r.client, cursor, data.RewindTo.ValueStringPointer()); err != nil {
+			resp.Diagnostics.AddError("Unable to rewind persistent cursor", err.Error())
+			return
+		}
+	}
+
+	if _, err := r.refreshCursor(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to read persistent cursor", err.Error())
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -347,9 +447,16 @@ func (r *PersistentCursorResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, fmt.Sprintf("Deleting persistent cursor %s for cluster %s", data.Key, data.ClusterId))
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = $1", persistentCursorTable), data.Key.ValueString())
+		_, err := db.ExecEx(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1", data.cursorRef().table(persistentCursorTable)), nil, data.Key.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		_, err = db.ExecEx(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1", data.cursorRef().table(persistentCursorHistoryTable)), nil, data.Key.ValueString())
 		return nil, err
 	})
 
@@ -360,23 +467,25 @@ func (r *PersistentCursorResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *PersistentCursorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	clusterId, key, err := ParseCursorId(req.ID)
+	cursor, err := ParseCursorId(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid persistent cursor resource ID", err.Error())
 		return
 	}
 
-	cursorValue, err := GetCursor(ctx, r.client, clusterId, key)
+	cursorValue, err := GetCursor(ctx, r.client, cursor)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to get persistent cursor with key: '%s' for cluster: '%s'", key, clusterId), err.Error())
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to get persistent cursor with key: '%s' for cluster: '%s'", cursor.Key, cursor.ClusterId), err.Error())
 		return
 	}
 
 	var data PersistentCursorResourceModel
-	data.Id = types.StringValue(req.ID)
+	data.Id = types.StringValue(cursor.Id())
 	data.Ref = data.Id
-	data.ClusterId = types.StringValue(clusterId)
-	data.Key = types.StringValue(key)
+	data.ClusterId = types.StringValue(cursor.ClusterId)
+	data.Database = types.StringValue(cursor.Database)
+	data.Schema = types.StringValue(cursor.Schema)
+	data.Key = types.StringValue(cursor.Key)
 	if cursorValue.Offset != nil {
 		data.ResumeOffset = types.Int64Value(*cursorValue.Offset)
 	}
@@ -387,5 +496,7 @@ func (r *PersistentCursorResource) ImportState(ctx context.Context, req resource
 		data.HighWaterMark = types.StringValue(*cursorValue.OffsetCursor)
 	}
 
+	data.Timeouts = nullTimeouts()
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,217 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+const (
+	persistentCursorTable         = "persistent_cursors"
+	persistentCursorMetadataTable = "persistent_cursor_metadata"
+	persistentCursorHistoryTable  = "persistent_cursor_history"
+
+	defaultCursorDatabase = "defaultdb"
+	defaultCursorSchema   = "public"
+)
+
+// PersistentCursorRef identifies a cursor and the tables that hold it.
+type PersistentCursorRef struct {
+	ClusterId string
+	Database  string
+	Schema    string
+	Key       string
+}
+
+// Id returns the resource ID of the cursor. Cursors in the default location keep the original
+// cursor|<cluster_id>|<key> format.
+func (c PersistentCursorRef) Id() string {
+	if c.Database == defaultCursorDatabase && c.Schema == defaultCursorSchema {
+		return fmt.Sprintf("cursor|%s|%s", c.ClusterId, c.Key)
+	}
+	return fmt.Sprintf("cursor|%s|%s|%s|%s", c.ClusterId, c.Database, c.Schema, c.Key)
+}
+
+func (c PersistentCursorRef) location() string {
+	return fmt.Sprintf("%s|%s|%s", c.ClusterId, c.Database, c.Schema)
+}
+
+// table returns the qualified name of one of the cursor tables.
+func (c PersistentCursorRef) table(name string) string {
+	return pgx.Identifier{c.Database, c.Schema, name}.Sanitize()
+}
+
+func ParseCursorId(cursorId string) (PersistentCursorRef, error) {
+	parts := strings.Split(cursorId, "|")
+	switch {
+	case len(parts) == 3 && parts[0] == "cursor":
+		return PersistentCursorRef{ClusterId: parts[1], Database: defaultCursorDatabase, Schema: defaultCursorSchema, Key: parts[2]}, nil
+	case len(parts) == 5 && parts[0] == "cursor":
+		return PersistentCursorRef{ClusterId: parts[1], Database: parts[2], Schema: parts[3], Key: parts[4]}, nil
+	}
+	return PersistentCursorRef{}, fmt.Errorf("unable to parse cursor ID %s", cursorId)
+}
+
+// cursorTableMigration moves the cursor tables to version. Statements must be idempotent, a migration
+// interrupted before the version is recorded runs again.
+type cursorTableMigration struct {
+	version    int
+	statements func(cursor PersistentCursorRef) []string
+}
+
+// cursorTableMigrations are applied in order to bring the cursor tables to the latest version.
+var cursorTableMigrations = []cursorTableMigration{
+	{
+		// Tables created before versioning already have this shape
+		version: 1,
+		statements: func(cursor PersistentCursorRef) []string {
+			return []string{
+				fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (key STRING PRIMARY KEY, resume_offset INT, last_used_job_id INT)", cursor.table(persistentCursorTable)),
+			}
+		},
+	},
+	{
+		// Checkpoint history and point-in-time rewind
+		version: 2,
+		statements: func(cursor PersistentCursorRef) []string {
+			return []string{
+				fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id INT PRIMARY KEY DEFAULT unique_rowid(),
+	key STRING NOT NULL,
+	job_id INT NOT NULL,
+	high_water_timestamp DECIMAL NOT NULL,
+	recorded_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (key, job_id, high_water_timestamp),
+	INDEX (key, recorded_at DESC)
+)`, cursor.table(persistentCursorHistoryTable)),
+				fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS rewind_to DECIMAL", cursor.table(persistentCursorTable)),
+			}
+		},
+	},
+}
+
+func latestCursorTableVersion() int {
+	return cursorTableMigrations[len(cursorTableMigrations)-1].version
+}
+
+// cursorTableNames are the tables whose write privileges are restricted to the provider.
+func cursorTableNames() []string {
+	return []string{persistentCursorTable, persistentCursorMetadataTable, persistentCursorHistoryTable}
+}
+
+// migratedCursorLocations tracks the cursor locations that are up to date in this provider run.
+var migratedCursorLocations = ccloud.NewSyncResourceHolder(map[string]bool{})
+
+// ensureCursorTable creates or migrates the cursor tables of the cursor location to the latest version and
+// restricts who can write to them.
+func ensureCursorTable(ctx context.Context, client *ccloud.CcloudClient, cursor PersistentCursorRef) error {
+	migrated, unlock := migratedCursorLocations.Get()
+	defer unlock()
+
+	if migrated[cursor.location()] {
+		return nil
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, client, cursor.ClusterId, "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		if cursor.Schema != defaultCursorSchema {
+			if _, err := db.ExecEx(ctx, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgx.Identifier{cursor.Database, cursor.Schema}.Sanitize()), nil); err != nil {
+				return nil, err
+			}
+		}
+
+		metadataTable := cursor.table(persistentCursorMetadataTable)
+		if _, err := db.ExecEx(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+	schema_version INT NOT NULL,
+	migrated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`, metadataTable), nil); err != nil {
+			return nil, err
+		}
+		if _, err := db.ExecEx(ctx, fmt.Sprintf("INSERT INTO %s (id, schema_version) VALUES (1, 0) ON CONFLICT (id) DO NOTHING", metadataTable), nil); err != nil {
+			return nil, err
+		}
+
+		var version int
+		if err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT schema_version FROM %s WHERE id = 1", metadataTable), nil).Scan(&version); err != nil {
+			return nil, err
+		}
+		if version > latestCursorTableVersion() {
+			return nil, fmt.Errorf("persistent cursor tables in %s.%s are at version %d, this provider only supports up to version %d", cursor.Database, cursor.Schema, version, latestCursorTableVersion())
+		}
+
+		for _, migration := range cursorTableMigrations {
+			if migration.version <= version {
+				continue
+			}
+			tflog.Info(ctx, fmt.Sprintf("Migrating persistent cursor tables in %s.%s to version %d", cursor.Database, cursor.Schema, migration.version))
+			for _, statement := range migration.statements(cursor) {
+				if _, err := db.ExecEx(ctx, statement, nil); err != nil {
+					return nil, fmt.Errorf("migrating persistent cursor tables to version %d: %w", migration.version, err)
+				}
+			}
+			if _, err := db.ExecEx(ctx, fmt.Sprintf("UPDATE %s SET schema_version = $1, migrated_at = now() WHERE id = 1 AND schema_version < $1", metadataTable), nil, migration.version); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, restrictCursorTableWrites(ctx, db, cursor)
+	})
+	if err != nil {
+		return err
+	}
+
+	migrated[cursor.location()] = true
+	return nil
+}
+
+// cursorTableWriters are the roles that keep write access to the cursor tables.
+var cursorTableWriters = []string{"admin", "root", ccloud.ClusterUserName}
+
+// restrictCursorTableWrites revokes write privileges on the cursor tables from every role except the provider,
+// other roles keep read access.
+func restrictCursorTableWrites(ctx context.Context, db *pgx.ConnPool, cursor PersistentCursorRef) error {
+	for _, name := range cursorTableNames() {
+		table := cursor.table(name)
+
+		rows, err := db.QueryEx(ctx, fmt.Sprintf("SELECT grantee, privilege_type FROM [SHOW GRANTS ON TABLE %s]", table), nil)
+		if err != nil {
+			return err
+		}
+		privileges := map[string][]string{}
+		for rows.Next() {
+			var grantee, privilege string
+			if err := rows.Scan(&grantee, &privilege); err != nil {
+				rows.Close()
+				return err
+			}
+			privileges[grantee] = append(privileges[grantee], privilege)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for grantee, granted := range privileges {
+			if slices.Contains(cursorTableWriters, grantee) {
+				continue
+			}
+			if !slices.ContainsFunc(granted, func(privilege string) bool { return privilege != "SELECT" }) {
+				continue
+			}
+			if _, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ALL ON TABLE %s FROM %s", table, pgx.Identifier{grantee}.Sanitize()), nil); err != nil {
+				return err
+			}
+			if slices.Contains(granted, "SELECT") || slices.Contains(granted, "ALL") {
+				if _, err := db.ExecEx(ctx, fmt.Sprintf("GRANT SELECT ON TABLE %s TO %s", table, pgx.Identifier{grantee}.Sanitize()), nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
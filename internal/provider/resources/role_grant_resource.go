@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -26,10 +28,12 @@ type RoleGrantResource struct {
 }
 
 type RoleGrantResourceModel struct {
-	ClusterId types.String `tfsdk:"cluster_id"`
-	Username  types.String `tfsdk:"user_name"`
-	Role      types.String `tfsdk:"role_name"`
-	Id        types.String `tfsdk:"id"`
+	ClusterId   types.String   `tfsdk:"cluster_id"`
+	Username    types.String   `tfsdk:"user_name"`
+	Role        types.String   `tfsdk:"role_name"`
+	AdminOption types.Bool     `tfsdk:"admin_option"`
+	Id          types.String   `tfsdk:"id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func buildRoleGrantId(clusterId string, username string, role string) string {
@@ -47,6 +51,34 @@ func parseRoleGrantId(id string) (clusterId string, username string, role string
 	return parts[1], parts[2], parts[3], nil
 }
 
+func grantRoleStatement(role string, username string, adminOption bool) string {
+	statement := fmt.Sprintf("GRANT %s TO %s", pgx.Identifier{role}.Sanitize(), pgx.Identifier{username}.Sanitize())
+	if adminOption {
+		statement += " WITH ADMIN OPTION"
+	}
+	return statement
+}
+
+// getRoleMembers returns the members of a role mapped to whether they hold the admin option.
+func getRoleMembers(ctx context.Context, db *pgx.ConnPool, role string) (map[string]bool, error) {
+	rows, err := db.QueryEx(ctx, fmt.Sprintf("SELECT member, is_admin FROM [SHOW GRANTS ON ROLE %s]", pgx.Identifier{role}.Sanitize()), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := map[string]bool{}
+	for rows.Next() {
+		var member string
+		var isAdmin bool
+		if err := rows.Scan(&member, &isAdmin); err != nil {
+			return nil, err
+		}
+		members[member] = isAdmin
+	}
+	return members, rows.Err()
+}
+
 func (r *RoleGrantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_role_grant"
 }
@@ -76,6 +108,12 @@ func (r *RoleGrantResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"admin_option": schema.BoolAttribute{
+				MarkdownDescription: "Grant the role `WITH ADMIN OPTION`, allowing the user to grant the role to others",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "ID",
 				Computed:            true,
@@ -83,6 +121,9 @@ func (r *RoleGrantResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            false,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -104,14 +145,17 @@ func (r *RoleGrantResource) Configure(ctx context.Context, req resource.Configur
 func (r *RoleGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RoleGrantResourceModel
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("GRANT %s TO %s", pgx.Identifier{data.Role.ValueString()}.Sanitize(), pgx.Identifier{data.Username.ValueString()}.Sanitize()))
+		_, err := db.ExecEx(ctx, grantRoleStatement(data.Role.ValueString(), data.Username.ValueString(), data.AdminOption.ValueBool()), nil)
 		return nil, err
 	})
 
@@ -134,16 +178,11 @@ func (r *RoleGrantResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	result, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
-		// If the role is not found, the query will return an empty row
-		var result bool
-		var response int
-		err := db.QueryRow(fmt.Sprintf("select 1 from [show grants on role %s] where member=$1", pgx.Identifier{data.Role.ValueString()}.Sanitize()), data.Username.ValueString()).Scan(&response)
-		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-			return nil, err
-		}
-		result = !errors.Is(err, pgx.ErrNoRows)
-		return &result, nil
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	result, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*roleGrantInfo, error) {
+		return getRoleGrant(ctx, db, data.Role.ValueString(), data.Username.ValueString())
 	})
 
 	if err != nil && !errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) && !errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
@@ -151,21 +190,64 @@ func (r *RoleGrantResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	if !*result {
+	if result == nil || !result.exists {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	data.AdminOption = types.BoolValue(result.isAdmin)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Update Role grants should never be updated in place, as they are immutable.
-// Throw an error if the user tries to do so.
+type roleGrantInfo struct {
+	exists  bool
+	isAdmin bool
+}
+
+func getRoleGrant(ctx context.Context, db *pgx.ConnPool, role string, username string) (*roleGrantInfo, error) {
+	// If the role is not found, the query will return an empty row
+	var isAdmin bool
+	err := db.QueryRowEx(ctx, fmt.Sprintf("select is_admin from [show grants on role %s] where member=$1", pgx.Identifier{role}.Sanitize()), nil, username).Scan(&isAdmin)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &roleGrantInfo{exists: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &roleGrantInfo{exists: true, isAdmin: isAdmin}, nil
+}
+
+// Update Only the admin option can be changed in place, the role and user are immutable.
 func (r *RoleGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data RoleGrantResourceModel
+
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
-	resp.Diagnostics.AddError("Role grants cannot be updated in place", "Role grants cannot be updated in place. Please delete the resource and recreate it.")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		if data.AdminOption.ValueBool() {
+			_, err := db.ExecEx(ctx, grantRoleStatement(data.Role.ValueString(), data.Username.ValueString(), true), nil)
+			return nil, err
+		}
+		_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ADMIN OPTION FOR %s FROM %s", pgx.Identifier{data.Role.ValueString()}.Sanitize(), pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil)
+		return nil, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update role grant", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildRoleGrantId(data.ClusterId.ValueString(), data.Username.ValueString(), data.Role.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RoleGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -177,12 +259,15 @@ func (r *RoleGrantResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	if data.Role.IsNull() || data.Username.IsNull() {
 		return
 	}
 
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("REVOKE %s FROM %s", pgx.Identifier{data.Role.ValueString()}.Sanitize(), pgx.Identifier{data.Username.ValueString()}.Sanitize()))
+		_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE %s FROM %s", pgx.Identifier{data.Role.ValueString()}.Sanitize(), pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil)
 		return nil, err
 	})
 
@@ -201,28 +286,20 @@ func (r *RoleGrantResource) ImportState(ctx context.Context, req resource.Import
 		return
 	}
 
-	exists, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
-		// If the role is not found, the query will return an empty row
-		var result bool
-		var response int
-		err := db.QueryRow(fmt.Sprintf("select 1 from [show grants on role %s] where member=$1", pgx.Identifier{role}.Sanitize()), username).Scan(&response)
-		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-			return nil, err
-		}
-		result = !errors.Is(err, pgx.ErrNoRows)
-		return &result, nil
+	grant, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*roleGrantInfo, error) {
+		return getRoleGrant(ctx, db, role, username)
 	})
 
 	if err != nil {
 		if errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
-			*exists = false
+			grant = &roleGrantInfo{exists: false}
 		} else {
 			resp.Diagnostics.AddError("error importing role grant", err.Error())
 			return
 		}
 	}
 
-	if !*exists {
+	if !grant.exists {
 		resp.Diagnostics.AddError("Failed to import role grant", fmt.Sprintf("Rolegrant with for user: '%s' and role: '%s' does not exist", username, role))
 		return
 	}
@@ -231,7 +308,10 @@ func (r *RoleGrantResource) ImportState(ctx context.Context, req resource.Import
 	data.ClusterId = types.StringValue(clusterId)
 	data.Username = types.StringValue(username)
 	data.Role = types.StringValue(role)
+	data.AdminOption = types.BoolValue(grant.isAdmin)
 	data.Id = types.StringValue(req.ID)
 
+	data.Timeouts = nullTimeouts()
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,405 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &RoleGrantsResource{}
+var _ resource.ResourceWithImportState = &RoleGrantsResource{}
+
+func NewRoleGrantsResource() resource.Resource {
+	return &RoleGrantsResource{}
+}
+
+type RoleGrantsResource struct {
+	client *ccloud.CcloudClient
+}
+
+type RoleGrantsMemberModel struct {
+	Username    types.String `tfsdk:"user_name"`
+	AdminOption types.Bool   `tfsdk:"admin_option"`
+}
+
+type RoleGrantsResourceModel struct {
+	ClusterId types.String   `tfsdk:"cluster_id"`
+	Role      types.String   `tfsdk:"role_name"`
+	Members   types.Set      `tfsdk:"members"`
+	Id        types.String   `tfsdk:"id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+var roleGrantsMemberAttrTypes = map[string]attr.Type{
+	"user_name":    types.StringType,
+	"admin_option": types.BoolType,
+}
+
+// Members that are never revoked, removing them would break the cluster or the provider itself.
+var unmanagedRoleMembers = []string{"root", ccloud.ClusterUserName}
+
+func buildRoleGrantsId(clusterId string, role string) string {
+	return "role_grants|" + clusterId + "|" + role
+}
+
+func parseRoleGrantsId(id string) (clusterId string, role string, err error) {
+	parts := strings.Split(id, "|")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("invalid role_grants resource ID")
+	}
+	if parts[0] != "role_grants" {
+		return "", "", fmt.Errorf("resource id must start with 'role_grants'")
+	}
+	return parts[1], parts[2], nil
+}
+
+func (r *RoleGrantsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_grants"
+}
+
+func (r *RoleGrantsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Authoritatively manage the members of a role.
+Any member of the role that is not listed in ` + "`members`" + ` will be revoked.
+The ` + "`root`" + ` user and the provider's own temporary user are never revoked.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetNestedAttribute{
+				MarkdownDescription: "Complete set of members of the role",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							MarkdownDescription: "Username",
+							Required:            true,
+						},
+						"admin_option": schema.BoolAttribute{
+							MarkdownDescription: "Grant the role `WITH ADMIN OPTION`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID",
+				Computed:            true,
+				Required:            false,
+				Optional:            false,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *RoleGrantsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func isUnmanagedRoleMember(member string) bool {
+	return slices.Contains(unmanagedRoleMembers, member)
+}
+
+// reconcileRoleMembers makes the membership of role match the desired members exactly.
+func reconcileRoleMembers(ctx context.Context, db *pgx.ConnPool, role string, desired map[string]bool) error {
+	current, err := getRoleMembers(ctx, db, role)
+	if err != nil {
+		return err
+	}
+
+	for member, isAdmin := range desired {
+		currentIsAdmin, exists := current[member]
+		switch {
+		case !exists || (isAdmin && !currentIsAdmin):
+			tflog.Debug(ctx, fmt.Sprintf("Granting %s to %s", role, member))
+			if _, err := db.ExecEx(ctx, grantRoleStatement(role, member, isAdmin), nil); err != nil {
+				return err
+			}
+		case !isAdmin && currentIsAdmin:
+			tflog.Debug(ctx, fmt.Sprintf("Revoking admin option for %s from %s", role, member))
+			if _, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ADMIN OPTION FOR %s FROM %s", pgx.Identifier{role}.Sanitize(), pgx.Identifier{member}.Sanitize()), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for member := range current {
+		if _, ok := desired[member]; ok || isUnmanagedRoleMember(member) {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Revoking %s from %s", role, member))
+		if _, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE %s FROM %s", pgx.Identifier{role}.Sanitize(), pgx.Identifier{member}.Sanitize()), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (data *RoleGrantsResourceModel) desiredMembers(ctx context.Context) (map[string]bool, error) {
+	var members []RoleGrantsMemberModel
+	if diags := data.Members.ElementsAs(ctx, &members, false); diags.HasError() {
+		return nil, fmt.Errorf("unable to read members")
+	}
+
+	desired := map[string]bool{}
+	for _, member := range members {
+		if _, ok := desired[member.Username.ValueString()]; ok {
+			return nil, fmt.Errorf("user %s is listed more than once", member.Username.ValueString())
+		}
+		desired[member.Username.ValueString()] = member.AdminOption.ValueBool()
+	}
+	return desired, nil
+}
+
+func roleMembersToSet(members map[string]bool) (types.Set, error) {
+	values := []attr.Value{}
+	for member, isAdmin := range members {
+		if isUnmanagedRoleMember(member) {
+			continue
+		}
+		value, diags := types.ObjectValue(roleGrantsMemberAttrTypes, map[string]attr.Value{
+			"user_name":    types.StringValue(member),
+			"admin_option": types.BoolValue(isAdmin),
+		})
+		if diags.HasError() {
+			return types.SetNull(types.ObjectType{AttrTypes: roleGrantsMemberAttrTypes}), fmt.Errorf("unable to build member value")
+		}
+		values = append(values, value)
+	}
+	set, diags := types.SetValue(types.ObjectType{AttrTypes: roleGrantsMemberAttrTypes}, values)
+	if diags.HasError() {
+		return set, fmt.Errorf("unable to build members set")
+	}
+	return set, nil
+}
+
+func (r *RoleGrantsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleGrantsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	desired, err := data.desiredMembers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid members", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		return nil, reconcileRoleMembers(ctx, db, data.Role.ValueString(), desired)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to grant role", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildRoleGrantsId(data.ClusterId.ValueString(), data.Role.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantsResource) readMembers(ctx context.Context, clusterId string, role string) (*map[string]bool, error) {
+	return ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*map[string]bool, error) {
+		var exists bool
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", nil, role).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, nil
+		}
+
+		members, err := getRoleMembers(ctx, db, role)
+		if err != nil {
+			return nil, err
+		}
+		return &members, nil
+	})
+}
+
+func (r *RoleGrantsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleGrantsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	members, err := r.readMembers(ctx, data.ClusterId.ValueString(), data.Role.ValueString())
+
+	if err != nil && !errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) && !errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+		resp.Diagnostics.AddError("Failed to read role members", err.Error())
+		return
+	}
+
+	if members == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Members, err = roleMembersToSet(*members)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read role members", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleGrantsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	desired, err := data.desiredMembers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid members", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		return nil, reconcileRoleMembers(ctx, db, data.Role.ValueString(), desired)
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update role members", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleGrantsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleGrantsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	desired, err := data.desiredMembers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid members", err.Error())
+		return
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		for member := range desired {
+			if isUnmanagedRoleMember(member) {
+				continue
+			}
+			_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE %s FROM %s", pgx.Identifier{data.Role.ValueString()}.Sanitize(), pgx.Identifier{member}.Sanitize()), nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to revoke role", err.Error())
+		return
+	}
+}
+
+func (r *RoleGrantsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterId, role, err := parseRoleGrantsId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid role grants resource ID", err.Error())
+		return
+	}
+
+	members, err := r.readMembers(ctx, clusterId, role)
+	if err != nil {
+		resp.Diagnostics.AddError("error importing role grants", err.Error())
+		return
+	}
+
+	if members == nil {
+		resp.Diagnostics.AddError("Failed to import role grants", fmt.Sprintf("Role '%s' does not exist", role))
+		return
+	}
+
+	var data RoleGrantsResourceModel
+	data.ClusterId = types.StringValue(clusterId)
+	data.Role = types.StringValue(role)
+	data.Id = types.StringValue(req.ID)
+	data.Members, err = roleMembersToSet(*members)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to import role grants", err.Error())
+		return
+	}
+
+	data.Timeouts = nullTimeouts()
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
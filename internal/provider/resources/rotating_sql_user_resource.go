@@ -0,0 +1,548 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorhill/cronexpr"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &RotatingSqlUserResource{}
+var _ resource.ResourceWithModifyPlan = &RotatingSqlUserResource{}
+var _ resource.ResourceWithConfigValidators = &RotatingSqlUserResource{}
+
+func NewRotatingSqlUserResource() resource.Resource {
+	return &RotatingSqlUserResource{}
+}
+
+type RotatingSqlUserResource struct {
+	client *ccloud.CcloudClient
+}
+
+type RotatingSqlUserResourceModel struct {
+	ClusterId        types.String   `tfsdk:"cluster_id"`
+	NameA            types.String   `tfsdk:"name_a"`
+	NameB            types.String   `tfsdk:"name_b"`
+	Roles            types.Set      `tfsdk:"roles"`
+	RotationSchedule types.String   `tfsdk:"rotation_schedule"`
+	ActiveUser       types.String   `tfsdk:"active_user"`
+	Username         types.String   `tfsdk:"username"`
+	Password         types.String   `tfsdk:"password"`
+	RotatedAt        types.String   `tfsdk:"rotated_at"`
+	NextRotation     types.String   `tfsdk:"next_rotation"`
+	Id               types.String   `tfsdk:"id"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+func buildRotatingSqlUserId(clusterId string, nameA string, nameB string) string {
+	return fmt.Sprintf("rotating_user|%s|%s|%s", clusterId, nameA, nameB)
+}
+
+type distinctUserNamesValidator struct {
+	resource.ConfigValidator
+}
+
+func (v *distinctUserNamesValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v *distinctUserNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return "name_a and name_b must be different"
+}
+
+func (v *distinctUserNamesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RotatingSqlUserResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if data.NameA.IsUnknown() || data.NameB.IsUnknown() {
+		return
+	}
+	if data.NameA.ValueString() == data.NameB.ValueString() {
+		resp.Diagnostics.AddError("name_a and name_b must be different", "")
+	}
+}
+
+func (r *RotatingSqlUserResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		&distinctUserNamesValidator{},
+	}
+}
+
+func (r *RotatingSqlUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotating_sql_user"
+}
+
+func (r *RotatingSqlUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Manage a pair of SQL users with identical role grants whose passwords are rotated on a schedule.
+On every rotation the inactive user receives a fresh password and becomes the active one, while the previously active user keeps working until the next rotation.
+Rotation happens on the first apply after ` + "`next_rotation`" + ` has passed.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_a": schema.StringAttribute{
+				MarkdownDescription: "Username of the first underlying user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_b": schema.StringAttribute{
+				MarkdownDescription: "Username of the second underlying user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "Roles granted to both users",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"rotation_schedule": schema.StringAttribute{
+				MarkdownDescription: "Cron expression describing when the password should be rotated",
+				Required:            true,
+				Validators: []validator.String{
+					CronExpressionValidator(),
+				},
+			},
+			"active_user": schema.StringAttribute{
+				MarkdownDescription: "Which of the two users is currently active, either `a` or `b`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the currently active user",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the currently active user",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of the last rotation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"next_rotation": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp after which the next apply rotates the password",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				Required: false,
+				Optional: false,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *RotatingSqlUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func nextRotation(schedule string, from time.Time) (time.Time, error) {
+	expr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return expr.Next(from), nil
+}
+
+func rotationDue(data *RotatingSqlUserResourceModel) bool {
+	if data.NextRotation.IsNull() || data.NextRotation.IsUnknown() {
+		return false
+	}
+	next, err := time.Parse(time.RFC3339, data.NextRotation.ValueString())
+	if err != nil {
+		return true
+	}
+	return !time.Now().Before(next)
+}
+
+func (data *RotatingSqlUserResourceModel) activeUsername() string {
+	if data.ActiveUser.ValueString() == "b" {
+		return data.NameB.ValueString()
+	}
+	return data.NameA.ValueString()
+}
+
+func getUserRoles(ctx context.Context, db *pgx.ConnPool, username string) ([]string, error) {
+	rows, err := db.QueryEx(ctx, "SELECT role_name FROM [SHOW GRANTS ON ROLE] WHERE member = $1", nil, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// reconcileUserRoles grants and revokes roles so that username is a member of exactly the given roles.
+func reconcileUserRoles(ctx context.Context, db *pgx.ConnPool, username string, roles []string) error {
+	currentRoles, err := getUserRoles(ctx, db, username)
+	if err != nil {
+		return err
+	}
+
+	added, removed := stringListDelta(currentRoles, roles)
+	for _, role := range added {
+		if _, err := db.ExecEx(ctx, fmt.Sprintf("GRANT %s TO %s", pgx.Identifier{role}.Sanitize(), pgx.Identifier{username}.Sanitize()), nil); err != nil {
+			return err
+		}
+	}
+	for _, role := range removed {
+		if _, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE %s FROM %s", pgx.Identifier{role}.Sanitize(), pgx.Identifier{username}.Sanitize()), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RotatingSqlUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RotatingSqlUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotationDue(&state) {
+		tflog.Info(ctx, fmt.Sprintf("Password rotation for %s is due", state.Id.ValueString()))
+		plan.ActiveUser = types.StringUnknown()
+		plan.Username = types.StringUnknown()
+		plan.Password = types.StringUnknown()
+		plan.RotatedAt = types.StringUnknown()
+		plan.NextRotation = types.StringUnknown()
+	} else if !plan.RotationSchedule.Equal(state.RotationSchedule) {
+		plan.NextRotation = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *RotatingSqlUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RotatingSqlUserResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	var roles []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now().UTC()
+	next, err := nextRotation(data.RotationSchedule.ValueString(), now)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid rotation schedule", err.Error())
+		return
+	}
+
+	password := uuid.New().String()
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, fmt.Sprintf("CREATE USER %s WITH PASSWORD $1", pgx.Identifier{data.NameA.ValueString()}.Sanitize()), nil, password)
+		if err != nil {
+			return nil, err
+		}
+
+		// The inactive user has no password until the first rotation
+		_, err = db.ExecEx(ctx, fmt.Sprintf("CREATE USER %s", pgx.Identifier{data.NameB.ValueString()}.Sanitize()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, username := range []string{data.NameA.ValueString(), data.NameB.ValueString()} {
+			if err := reconcileUserRoles(ctx, db, username, roles); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("error creating rotating user", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildRotatingSqlUserId(data.ClusterId.ValueString(), data.NameA.ValueString(), data.NameB.ValueString()))
+	data.ActiveUser = types.StringValue("a")
+	data.Username = data.NameA
+	data.Password = types.StringValue(password)
+	data.RotatedAt = types.StringValue(now.Format(time.RFC3339))
+	data.NextRotation = types.StringValue(next.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RotatingSqlUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RotatingSqlUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	type userSet struct {
+		exists bool
+		rolesA []string
+		rolesB []string
+	}
+
+	users, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*userSet, error) {
+		var count int
+		err := db.QueryRowEx(ctx, "SELECT count(*) FROM [SHOW USERS] WHERE username IN ($1, $2)", nil, data.NameA.ValueString(), data.NameB.ValueString()).Scan(&count)
+		if err != nil {
+			return nil, err
+		}
+		if count != 2 {
+			return &userSet{exists: false}, nil
+		}
+
+		rolesA, err := getUserRoles(ctx, db, data.NameA.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		rolesB, err := getUserRoles(ctx, db, data.NameB.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return &userSet{exists: true, rolesA: rolesA, rolesB: rolesB}, nil
+	})
+
+	if err != nil {
+		if errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			users = &userSet{exists: false}
+		} else {
+			resp.Diagnostics.AddError("error checking rotating user", err.Error())
+			return
+		}
+	}
+
+	if !users.exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Report whichever user deviates from the last applied roles so that drift on either one shows up in the plan
+	var stateRoles []string
+	resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &stateRoles, false)...)
+	slices.Sort(stateRoles)
+	slices.Sort(users.rolesA)
+	slices.Sort(users.rolesB)
+
+	roles := users.rolesA
+	if slices.Equal(users.rolesA, stateRoles) {
+		roles = users.rolesB
+	}
+
+	if len(roles) == 0 && data.Roles.IsNull() {
+		data.Roles = types.SetNull(types.StringType)
+	} else {
+		roleValues := make([]attr.Value, len(roles))
+		for i, role := range roles {
+			roleValues[i] = types.StringValue(role)
+		}
+		data.Roles, _ = types.SetValue(types.StringType, roleValues)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RotatingSqlUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RotatingSqlUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	var roles []string
+	resp.Diagnostics.Append(plan.Roles.ElementsAs(ctx, &roles, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan marks the credentials unknown when a rotation is due, the clock may have moved on since planning
+	rotate := plan.Password.IsUnknown()
+
+	plan.Id = state.Id
+	plan.ActiveUser = state.ActiveUser
+	plan.Username = state.Username
+	plan.Password = state.Password
+	plan.RotatedAt = state.RotatedAt
+
+	var password string
+	if rotate {
+		if state.ActiveUser.ValueString() == "a" {
+			plan.ActiveUser = types.StringValue("b")
+		} else {
+			plan.ActiveUser = types.StringValue("a")
+		}
+		password = uuid.New().String()
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, plan.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		for _, username := range []string{plan.NameA.ValueString(), plan.NameB.ValueString()} {
+			if err := reconcileUserRoles(ctx, db, username, roles); err != nil {
+				return nil, err
+			}
+		}
+
+		if !rotate {
+			return nil, nil
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Rotating password, %s is now the active user", plan.activeUsername()))
+		_, err := db.ExecEx(ctx, fmt.Sprintf("ALTER USER %s WITH PASSWORD $1", pgx.Identifier{plan.activeUsername()}.Sanitize()), nil, password)
+		return nil, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("error updating rotating user", err.Error())
+		return
+	}
+
+	rotatedAt, err := time.Parse(time.RFC3339, state.RotatedAt.ValueString())
+	if err != nil {
+		rotatedAt = time.Now().UTC()
+	}
+
+	if rotate {
+		rotatedAt = time.Now().UTC()
+		plan.Username = types.StringValue(plan.activeUsername())
+		plan.Password = types.StringValue(password)
+		plan.RotatedAt = types.StringValue(rotatedAt.Format(time.RFC3339))
+	}
+
+	next, err := nextRotation(plan.RotationSchedule.ValueString(), rotatedAt)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid rotation schedule", err.Error())
+		return
+	}
+	plan.NextRotation = types.StringValue(next.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RotatingSqlUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RotatingSqlUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		for _, username := range []string{data.NameA.ValueString(), data.NameB.ValueString()} {
+			_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ALL ON * FROM %s", pgx.Identifier{username}.Sanitize()), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = db.ExecEx(ctx, fmt.Sprintf("DROP USER %s", pgx.Identifier{username}.Sanitize()), nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("error deleting rotating user", err.Error())
+		return
+	}
+}
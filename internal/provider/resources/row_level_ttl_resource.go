@@ -0,0 +1,603 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &RowLevelTtlResource{}
+var _ resource.ResourceWithImportState = &RowLevelTtlResource{}
+
+func NewRowLevelTtlResource() resource.Resource {
+	return &RowLevelTtlResource{}
+}
+
+type RowLevelTtlResource struct {
+	client *ccloud.CcloudClient
+}
+
+type RowLevelTtlResourceModel struct {
+	ClusterId                       types.String   `tfsdk:"cluster_id"`
+	Database                        types.String   `tfsdk:"database"`
+	Table                           types.String   `tfsdk:"table"`
+	TtlExpireAfter                  types.String   `tfsdk:"ttl_expire_after"`
+	TtlExpirationExpression         types.String   `tfsdk:"ttl_expiration_expression"`
+	TtlJobCron                      types.String   `tfsdk:"ttl_job_cron"`
+	TtlSelectBatchSize              types.Int64    `tfsdk:"ttl_select_batch_size"`
+	TtlDeleteBatchSize              types.Int64    `tfsdk:"ttl_delete_batch_size"`
+	TtlSelectRateLimit              types.Int64    `tfsdk:"ttl_select_rate_limit"`
+	TtlDeleteRateLimit              types.Int64    `tfsdk:"ttl_delete_rate_limit"`
+	TtlPause                        types.Bool     `tfsdk:"ttl_pause"`
+	TtlRowStatsPollInterval         types.String   `tfsdk:"ttl_row_stats_poll_interval"`
+	TtlLabelMetrics                 types.Bool     `tfsdk:"ttl_label_metrics"`
+	TtlDisableChangefeedReplication types.Bool     `tfsdk:"ttl_disable_changefeed_replication"`
+	Id                              types.String   `tfsdk:"id"`
+	Timeouts                        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func buildRowLevelTtlId(clusterId string, database string, table string) string {
+	return fmt.Sprintf("row_level_ttl|%s|%s|%s", clusterId, database, table)
+}
+
+// rowLevelTtlParam ties a storage parameter to the model attribute holding its value. Exactly one of the value pointers is set.
+type rowLevelTtlParam struct {
+	name        string
+	stringValue *types.String
+	int64Value  *types.Int64
+	boolValue   *types.Bool
+	// interval parameters are compared as durations, expression parameters as parsed SQL expressions
+	interval   bool
+	expression bool
+}
+
+func (data *RowLevelTtlResourceModel) params() []rowLevelTtlParam {
+	return []rowLevelTtlParam{
+		{name: "ttl_expire_after", stringValue: &data.TtlExpireAfter, interval: true},
+		{name: "ttl_expiration_expression", stringValue: &data.TtlExpirationExpression, expression: true},
+		{name: "ttl_job_cron", stringValue: &data.TtlJobCron},
+		{name: "ttl_select_batch_size", int64Value: &data.TtlSelectBatchSize},
+		{name: "ttl_delete_batch_size", int64Value: &data.TtlDeleteBatchSize},
+		{name: "ttl_select_rate_limit", int64Value: &data.TtlSelectRateLimit},
+		{name: "ttl_delete_rate_limit", int64Value: &data.TtlDeleteRateLimit},
+		{name: "ttl_pause", boolValue: &data.TtlPause},
+		{name: "ttl_row_stats_poll_interval", stringValue: &data.TtlRowStatsPollInterval, interval: true},
+		{name: "ttl_label_metrics", boolValue: &data.TtlLabelMetrics},
+		{name: "ttl_disable_changefeed_replication", boolValue: &data.TtlDisableChangefeedReplication},
+	}
+}
+
+func (p rowLevelTtlParam) isNull() bool {
+	switch {
+	case p.stringValue != nil:
+		return p.stringValue.IsNull()
+	case p.int64Value != nil:
+		return p.int64Value.IsNull()
+	default:
+		return p.boolValue.IsNull()
+	}
+}
+
+func (p rowLevelTtlParam) expr() tree.Expr {
+	switch {
+	case p.stringValue != nil:
+		return tree.NewStrVal(p.stringValue.ValueString())
+	case p.int64Value != nil:
+		return tree.NewDInt(tree.DInt(p.int64Value.ValueInt64()))
+	default:
+		return tree.MakeDBool(tree.DBool(p.boolValue.ValueBool()))
+	}
+}
+
+// equal reports whether the configured value of p is equivalent to the value of other.
+func (p rowLevelTtlParam) equal(other rowLevelTtlParam) bool {
+	switch {
+	case p.stringValue != nil:
+		a, b := p.stringValue.ValueString(), other.stringValue.ValueString()
+		return a == b || p.interval && equivalentIntervals(a, b) || p.expression && equivalentExpressions(a, b)
+	case p.int64Value != nil:
+		return p.int64Value.Equal(*other.int64Value)
+	default:
+		return p.boolValue.Equal(*other.boolValue)
+	}
+}
+
+func equivalentIntervals(a string, b string) bool {
+	durationA, errA := parseSettingDuration(a)
+	durationB, errB := parseSettingDuration(b)
+	return errA == nil && errB == nil && durationA == durationB
+}
+
+func equivalentExpressions(a string, b string) bool {
+	exprA, errA := parser.ParseExpr(a)
+	exprB, errB := parser.ParseExpr(b)
+	return errA == nil && errB == nil && exprA.String() == exprB.String()
+}
+
+// set updates the attribute of p from a storage parameter value as printed by SHOW CREATE TABLE.
+func (p rowLevelTtlParam) set(value tree.Expr) error {
+	raw := storageParamString(value)
+	switch {
+	case p.stringValue != nil:
+		*p.stringValue = types.StringValue(raw)
+	case p.int64Value != nil:
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer value for %s: %s", p.name, value.String())
+		}
+		*p.int64Value = types.Int64Value(i)
+	default:
+		b, err := parseSettingBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean value for %s: %s", p.name, value.String())
+		}
+		*p.boolValue = types.BoolValue(b)
+	}
+	return nil
+}
+
+// parse returns a copy of p holding value instead of the attribute value.
+func (p rowLevelTtlParam) parse(value tree.Expr) (rowLevelTtlParam, error) {
+	parsed := rowLevelTtlParam{name: p.name, interval: p.interval, expression: p.expression}
+	switch {
+	case p.stringValue != nil:
+		parsed.stringValue = &types.String{}
+	case p.int64Value != nil:
+		parsed.int64Value = &types.Int64{}
+	default:
+		parsed.boolValue = &types.Bool{}
+	}
+	return parsed, parsed.set(value)
+}
+
+func (p rowLevelTtlParam) copyFrom(other rowLevelTtlParam) {
+	switch {
+	case p.stringValue != nil:
+		*p.stringValue = *other.stringValue
+	case p.int64Value != nil:
+		*p.int64Value = *other.int64Value
+	default:
+		*p.boolValue = *other.boolValue
+	}
+}
+
+func (p rowLevelTtlParam) setNull() {
+	switch {
+	case p.stringValue != nil:
+		*p.stringValue = types.StringNull()
+	case p.int64Value != nil:
+		*p.int64Value = types.Int64Null()
+	default:
+		*p.boolValue = types.BoolNull()
+	}
+}
+
+// storageParamString unwraps type annotations like '00:10:00':::INTERVAL and returns the raw value.
+func storageParamString(value tree.Expr) string {
+	switch v := value.(type) {
+	case *tree.StrVal:
+		return v.RawString()
+	case *tree.AnnotateTypeExpr:
+		return storageParamString(v.Expr)
+	case *tree.CastExpr:
+		return storageParamString(v.Expr)
+	case *tree.DString:
+		return string(*v)
+	}
+	return removeQuotes(value.String())
+}
+
+func parseRowLevelTtlTable(table string) (*tree.TableName, error) {
+	return parser.ParseQualifiedTableName(table)
+}
+
+func (r *RowLevelTtlResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_row_level_ttl"
+}
+
+func (r *RowLevelTtlResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Row-level TTL of a table.
+Only the configured storage parameters are managed, destroying the resource disables row-level TTL with ` + "`RESET (ttl)`" + `.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database containing the table",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table": schema.StringAttribute{
+				MarkdownDescription: "Name of the table, optionally qualified with its schema",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl_expire_after": schema.StringAttribute{
+				MarkdownDescription: "Interval after which rows expire, e.g. `720h` or `30 days 00:00:00`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("ttl_expiration_expression")),
+				},
+			},
+			"ttl_expiration_expression": schema.StringAttribute{
+				MarkdownDescription: "SQL expression of type TIMESTAMPTZ that determines when a row expires, e.g. `created_at + INTERVAL '30 days'`",
+				Optional:            true,
+			},
+			"ttl_job_cron": schema.StringAttribute{
+				MarkdownDescription: "Cron expression for how often the TTL job runs",
+				Optional:            true,
+				Validators: []validator.String{
+					CronExpressionValidator(),
+				},
+			},
+			"ttl_select_batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of rows to select at once while looking for expired rows",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ttl_delete_batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of rows to delete at once",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ttl_select_rate_limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of records selected per second per node",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ttl_delete_rate_limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of records deleted per second per node",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ttl_pause": schema.BoolAttribute{
+				MarkdownDescription: "Stop the TTL job from deleting rows",
+				Optional:            true,
+			},
+			"ttl_row_stats_poll_interval": schema.StringAttribute{
+				MarkdownDescription: "Interval at which row statistics are collected, e.g. `1m`",
+				Optional:            true,
+			},
+			"ttl_label_metrics": schema.BoolAttribute{
+				MarkdownDescription: "Label TTL job metrics with the table name",
+				Optional:            true,
+			},
+			"ttl_disable_changefeed_replication": schema.BoolAttribute{
+				MarkdownDescription: "Do not emit TTL deletes to changefeeds watching the table",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Row-level TTL ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *RowLevelTtlResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+// alterStorageParams sets every parameter that differs from previous and resets every parameter only set in previous.
+func (r *RowLevelTtlResource) alterStorageParams(ctx context.Context, data *RowLevelTtlResourceModel, previous *RowLevelTtlResourceModel) error {
+	table, err := parseRowLevelTtlTable(data.Table.ValueString())
+	if err != nil {
+		return err
+	}
+
+	var setParams tree.StorageParams
+	var resetParams tree.NameList
+
+	params := data.params()
+	var previousParams []rowLevelTtlParam
+	if previous != nil {
+		previousParams = previous.params()
+	}
+
+	for i, param := range params {
+		previouslySet := previousParams != nil && !previousParams[i].isNull()
+		if param.isNull() {
+			if previouslySet {
+				resetParams = append(resetParams, tree.Name(param.name))
+			}
+			continue
+		}
+		if previouslySet && param.equal(previousParams[i]) {
+			continue
+		}
+		setParams = append(setParams, tree.StorageParam{Key: tree.Name(param.name), Value: param.expr()})
+	}
+
+	var cmds tree.AlterTableCmds
+	if len(setParams) > 0 {
+		cmds = append(cmds, &tree.AlterTableSetStorageParams{StorageParams: setParams})
+	}
+	if len(resetParams) > 0 {
+		cmds = append(cmds, &tree.AlterTableResetStorageParams{Params: resetParams})
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	statement := tree.AlterTable{
+		Table: table.ToUnresolvedObjectName(),
+		Cmds:  cmds,
+	}
+	query := statement.String()
+
+	tflog.Info(ctx, fmt.Sprintf("Updating row-level TTL with query: %s", query))
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), data.Database.ValueString(), func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, query, nil)
+		return nil, err
+	})
+	return err
+}
+
+type RowLevelTtlNotFoundError struct {
+	Table string
+}
+
+func (e RowLevelTtlNotFoundError) Error() string {
+	return fmt.Sprintf("row-level TTL is not enabled on %s", e.Table)
+}
+
+// getStorageParams returns the storage parameters of the table from SHOW CREATE TABLE.
+func (r *RowLevelTtlResource) getStorageParams(ctx context.Context, clusterId string, database string, tableName string) (map[string]tree.Expr, error) {
+	table, err := parseRowLevelTtlTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	createStatement, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, database, func(db *pgx.ConnPool) (*string, error) {
+		var createStatement string
+		err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT create_statement FROM [SHOW CREATE TABLE %s]", table.String()), nil).Scan(&createStatement)
+		if err != nil {
+			return nil, err
+		}
+		return &createStatement, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := parser.ParseOne(*createStatement)
+	if err != nil {
+		return nil, err
+	}
+	createTable, ok := statement.AST.(*tree.CreateTable)
+	if !ok {
+		return nil, fmt.Errorf("unexpected create statement for %s: %s", tableName, *createStatement)
+	}
+
+	params := map[string]tree.Expr{}
+	for _, param := range createTable.StorageParams {
+		params[string(param.Key)] = param.Value
+	}
+
+	if _, ok := params["ttl"]; !ok {
+		return nil, RowLevelTtlNotFoundError{Table: tableName}
+	}
+
+	return params, nil
+}
+
+// applyStorageParams updates data from the table storage parameters. When all is false only attributes that are already set are updated.
+func (data *RowLevelTtlResourceModel) applyStorageParams(storageParams map[string]tree.Expr, all bool) error {
+	for _, param := range data.params() {
+		if !all && param.isNull() {
+			continue
+		}
+
+		value, ok := storageParams[param.name]
+		if !ok {
+			param.setNull()
+			continue
+		}
+
+		currentParam, err := param.parse(value)
+		if err != nil {
+			return err
+		}
+
+		// Keep the configured representation as long as it is equivalent to the current value
+		if param.isNull() || !param.equal(currentParam) {
+			param.copyFrom(currentParam)
+		}
+	}
+	return nil
+}
+
+func (r *RowLevelTtlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RowLevelTtlResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.alterStorageParams(ctx, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Unable to enable row-level TTL", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildRowLevelTtlId(data.ClusterId.ValueString(), data.Database.ValueString(), data.Table.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RowLevelTtlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RowLevelTtlResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	storageParams, err := r.getStorageParams(ctx, data.ClusterId.ValueString(), data.Database.ValueString(), data.Table.ValueString())
+	if err != nil {
+		var notFound RowLevelTtlNotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read row-level TTL", err.Error())
+		return
+	}
+
+	if err := data.applyStorageParams(storageParams, false); err != nil {
+		resp.Diagnostics.AddError("Unable to read row-level TTL", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RowLevelTtlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RowLevelTtlResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.alterStorageParams(ctx, &plan, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to update row-level TTL", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RowLevelTtlResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RowLevelTtlResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	table, err := parseRowLevelTtlTable(data.Table.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to disable row-level TTL", err.Error())
+		return
+	}
+
+	statement := tree.AlterTable{
+		Table: table.ToUnresolvedObjectName(),
+		Cmds: tree.AlterTableCmds{
+			&tree.AlterTableResetStorageParams{Params: tree.NameList{"ttl"}},
+		},
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), data.Database.ValueString(), func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, statement.String(), nil)
+		return nil, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to disable row-level TTL", err.Error())
+		return
+	}
+}
+
+func (r *RowLevelTtlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 4 || idParts[0] != "row_level_ttl" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format row_level_ttl|<cluster_id>|<database>|<table>, got: %s", req.ID))
+		return
+	}
+
+	data := RowLevelTtlResourceModel{
+		ClusterId: types.StringValue(idParts[1]),
+		Database:  types.StringValue(idParts[2]),
+		Table:     types.StringValue(idParts[3]),
+		Id:        types.StringValue(req.ID),
+		Timeouts:  nullTimeouts(),
+	}
+
+	storageParams, err := r.getStorageParams(ctx, data.ClusterId.ValueString(), data.Database.ValueString(), data.Table.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read row-level TTL", err.Error())
+		return
+	}
+
+	if err := data.applyStorageParams(storageParams, true); err != nil {
+		resp.Diagnostics.AddError("Unable to read row-level TTL", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
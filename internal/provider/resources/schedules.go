@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jackc/pgx"
+)
+
+// buildScheduleOptions builds the WITH SCHEDULE OPTIONS entries shared by every kind of schedule.
+func buildScheduleOptions(firstRun types.String, onExecutionFailure types.String, onPreviousRunning types.String) []string {
+	scheduleOptionsSet := []string{}
+
+	if !firstRun.IsNull() {
+		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("first_run=%s", SanatizeValue(firstRun.ValueString())))
+	}
+
+	if !onExecutionFailure.IsNull() {
+		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("on_execution_failure=%s", SanatizeValue(onExecutionFailure.ValueString())))
+	}
+
+	if !onPreviousRunning.IsNull() {
+		scheduleOptionsSet = append(scheduleOptionsSet, fmt.Sprintf("on_previous_running=%s", SanatizeValue(onPreviousRunning.ValueString())))
+	}
+
+	return scheduleOptionsSet
+}
+
+var mapOnExecutionFailure = map[string]string{
+	"PAUSE_SCHED": "pause",
+	"RETRY_SOON":  "retry",
+	"RETRY_SCHED": "reschedule",
+}
+
+// scheduleOnExecutionFailure converts the on_execution_failure column of SHOW SCHEDULES into the schedule option value.
+func scheduleOnExecutionFailure(onExecutionFailure string) string {
+	if val, ok := mapOnExecutionFailure[onExecutionFailure]; ok {
+		return val
+	}
+	return "retry"
+}
+
+// scheduleOnPreviousRunning converts the on_previous_running column of SHOW SCHEDULES into the schedule option value.
+func scheduleOnPreviousRunning(onPreviousRunning string) string {
+	return strings.ToLower(onPreviousRunning)
+}
+
+type ScheduleNotFoundError struct {
+	Label string
+}
+
+func (e ScheduleNotFoundError) Error() string {
+	return fmt.Sprintf("schedule %s not found", e.Label)
+}
+
+type scheduleStatus struct {
+	Id         int64
+	Label      string
+	Status     string
+	State      *string
+	NextRun    *time.Time
+	Recurrence *string
+}
+
+func (s *scheduleStatus) Paused() bool {
+	return s.Status == "PAUSED"
+}
+
+// NextRunValue returns next_run formatted as RFC 3339, paused schedules have no next run.
+func (s *scheduleStatus) NextRunValue() types.String {
+	if s.NextRun == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(s.NextRun.UTC().Format(time.RFC3339))
+}
+
+func (s *scheduleStatus) StateValue() types.String {
+	if s.State == nil {
+		return types.StringValue("")
+	}
+	return types.StringValue(*s.State)
+}
+
+const showScheduleStatusQuery = "SELECT id, label, schedule_status, state, next_run, recurrence FROM [SHOW SCHEDULES]"
+
+func getScheduleStatusByLabel(ctx context.Context, db *pgx.ConnPool, label string) (*scheduleStatus, error) {
+	status := scheduleStatus{}
+	err := db.QueryRowEx(ctx, showScheduleStatusQuery+" WHERE label = $1", nil, label).
+		Scan(&status.Id, &status.Label, &status.Status, &status.State, &status.NextRun, &status.Recurrence)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ScheduleNotFoundError{Label: label}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func getScheduleStatusById(ctx context.Context, db *pgx.ConnPool, id int64) (*scheduleStatus, error) {
+	status := scheduleStatus{}
+	err := db.QueryRowEx(ctx, showScheduleStatusQuery+" WHERE id = $1", nil, id).
+		Scan(&status.Id, &status.Label, &status.Status, &status.State, &status.NextRun, &status.Recurrence)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ScheduleNotFoundError{Label: fmt.Sprint(id)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// execSetSchedulePaused pauses or resumes the schedule.
+func execSetSchedulePaused(ctx context.Context, db *pgx.ConnPool, id int64, paused bool) error {
+	command := "RESUME"
+	if paused {
+		command = "PAUSE"
+	}
+	_, err := db.ExecEx(ctx, fmt.Sprintf("%s SCHEDULE %d", command, id), nil)
+	return err
+}
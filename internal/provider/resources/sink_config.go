@@ -0,0 +1,394 @@
+package resources
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SinkFlushConfigModel struct {
+	Messages  types.Int64  `tfsdk:"messages"`
+	Bytes     types.Int64  `tfsdk:"bytes"`
+	Frequency types.String `tfsdk:"frequency"`
+}
+
+type KafkaSinkConfigModel struct {
+	Flush        *SinkFlushConfigModel `tfsdk:"flush"`
+	RequiredAcks types.String          `tfsdk:"required_acks"`
+	Compression  types.String          `tfsdk:"compression"`
+	ClientId     types.String          `tfsdk:"client_id"`
+	Version      types.String          `tfsdk:"version"`
+}
+
+type SinkRetryConfigModel struct {
+	Max     types.String `tfsdk:"max"`
+	Backoff types.String `tfsdk:"backoff"`
+}
+
+type WebhookSinkConfigModel struct {
+	Flush *SinkFlushConfigModel `tfsdk:"flush"`
+	Retry *SinkRetryConfigModel `tfsdk:"retry"`
+}
+
+var goDurationRegex = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
+func sinkFlushConfigAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "When batched messages are flushed to the sink",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"messages": schema.Int64Attribute{
+				MarkdownDescription: "Number of messages to batch before flushing",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"bytes": schema.Int64Attribute{
+				MarkdownDescription: "Number of bytes to batch before flushing",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"frequency": schema.StringAttribute{
+				MarkdownDescription: "How long to wait before flushing, like `1s`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(goDurationRegex, "Frequency must be a duration like 500ms or 1s"),
+				},
+			},
+		},
+	}
+}
+
+func kafkaSinkConfigAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Typed form of `kafka_sink_config`, rendered to JSON",
+		Optional:            true,
+		Validators: []validator.Object{
+			objectvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("kafka_sink_config")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"flush": sinkFlushConfigAttribute(),
+			"required_acks": schema.StringAttribute{
+				MarkdownDescription: "Number of acknowledgements required from the brokers",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ONE", "ALL", "NONE"),
+				},
+			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "Compression of the messages",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("NONE", "GZIP", "SNAPPY", "LZ4", "ZSTD"),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID reported to the brokers",
+				Optional:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Kafka protocol version",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func webhookSinkConfigAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Typed form of `webhook_sink_config`, rendered to JSON",
+		Optional:            true,
+		Validators: []validator.Object{
+			objectvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("webhook_sink_config")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"flush": sinkFlushConfigAttribute(),
+			"retry": schema.SingleNestedAttribute{
+				MarkdownDescription: "How failed requests are retried",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"max": schema.StringAttribute{
+						MarkdownDescription: "Maximum number of retries, or `inf`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9]+|inf)$`), "Max must be a number or inf"),
+						},
+					},
+					"backoff": schema.StringAttribute{
+						MarkdownDescription: "Initial backoff between retries, like `500ms`",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(goDurationRegex, "Backoff must be a duration like 500ms or 1s"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f *SinkFlushConfigModel) toJson() map[string]interface{} {
+	flush := map[string]interface{}{}
+	if !f.Messages.IsNull() {
+		flush["Messages"] = f.Messages.ValueInt64()
+	}
+	if !f.Bytes.IsNull() {
+		flush["Bytes"] = f.Bytes.ValueInt64()
+	}
+	if !f.Frequency.IsNull() {
+		flush["Frequency"] = f.Frequency.ValueString()
+	}
+	return flush
+}
+
+// Json renders the config as canonical JSON, keys are sorted and unset fields are omitted.
+func (c *KafkaSinkConfigModel) Json() string {
+	config := map[string]interface{}{}
+	if c.Flush != nil {
+		config["Flush"] = c.Flush.toJson()
+	}
+	if !c.RequiredAcks.IsNull() {
+		config["RequiredAcks"] = c.RequiredAcks.ValueString()
+	}
+	if !c.Compression.IsNull() {
+		config["Compression"] = c.Compression.ValueString()
+	}
+	if !c.ClientId.IsNull() {
+		config["ClientID"] = c.ClientId.ValueString()
+	}
+	if !c.Version.IsNull() {
+		config["Version"] = c.Version.ValueString()
+	}
+	rendered, _ := json.Marshal(config)
+	return string(rendered)
+}
+
+// Json renders the config as canonical JSON, keys are sorted and unset fields are omitted.
+func (c *WebhookSinkConfigModel) Json() string {
+	config := map[string]interface{}{}
+	if c.Flush != nil {
+		config["Flush"] = c.Flush.toJson()
+	}
+	if c.Retry != nil {
+		retry := map[string]interface{}{}
+		if !c.Retry.Max.IsNull() {
+			if max, err := strconv.ParseInt(c.Retry.Max.ValueString(), 10, 64); err == nil {
+				retry["Max"] = max
+			} else {
+				retry["Max"] = c.Retry.Max.ValueString()
+			}
+		}
+		if !c.Retry.Backoff.IsNull() {
+			retry["Backoff"] = c.Retry.Backoff.ValueString()
+		}
+		config["Retry"] = retry
+	}
+	rendered, _ := json.Marshal(config)
+	return string(rendered)
+}
+
+// jsonObjectFields decodes a JSON object and checks that it only has the allowed keys.
+func jsonObjectFields(value interface{}, allowed ...string) (map[string]interface{}, bool) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	for key := range fields {
+		if !slices.Contains(allowed, key) {
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+func jsonInt64(value interface{}) (types.Int64, bool) {
+	number, ok := value.(float64)
+	if !ok || number != float64(int64(number)) {
+		return types.Int64Null(), false
+	}
+	return types.Int64Value(int64(number)), true
+}
+
+func jsonString(value interface{}) (types.String, bool) {
+	str, ok := value.(string)
+	if !ok {
+		return types.StringNull(), false
+	}
+	return types.StringValue(str), true
+}
+
+func parseSinkFlushConfig(value interface{}) (*SinkFlushConfigModel, bool) {
+	fields, ok := jsonObjectFields(value, "Messages", "Bytes", "Frequency")
+	if !ok {
+		return nil, false
+	}
+	flush := SinkFlushConfigModel{}
+	if v, set := fields["Messages"]; set {
+		if flush.Messages, ok = jsonInt64(v); !ok {
+			return nil, false
+		}
+	}
+	if v, set := fields["Bytes"]; set {
+		if flush.Bytes, ok = jsonInt64(v); !ok {
+			return nil, false
+		}
+	}
+	if v, set := fields["Frequency"]; set {
+		if flush.Frequency, ok = jsonString(v); !ok {
+			return nil, false
+		}
+	}
+	return &flush, true
+}
+
+// parseKafkaSinkConfig converts the JSON kafka_sink_config option into the typed config.
+// It fails when the JSON uses fields the typed config does not support.
+func parseKafkaSinkConfig(raw string) (*KafkaSinkConfigModel, bool) {
+	var value interface{}
+	if json.Unmarshal([]byte(raw), &value) != nil {
+		return nil, false
+	}
+	fields, ok := jsonObjectFields(value, "Flush", "RequiredAcks", "Compression", "ClientID", "Version")
+	if !ok {
+		return nil, false
+	}
+	config := KafkaSinkConfigModel{}
+	if v, set := fields["Flush"]; set {
+		if config.Flush, ok = parseSinkFlushConfig(v); !ok {
+			return nil, false
+		}
+	}
+	for key, field := range map[string]*types.String{
+		"RequiredAcks": &config.RequiredAcks,
+		"Compression":  &config.Compression,
+		"ClientID":     &config.ClientId,
+		"Version":      &config.Version,
+	} {
+		if v, set := fields[key]; set {
+			if *field, ok = jsonString(v); !ok {
+				return nil, false
+			}
+		}
+	}
+	return &config, true
+}
+
+// parseWebhookSinkConfig converts the JSON webhook_sink_config option into the typed config.
+// It fails when the JSON uses fields the typed config does not support.
+func parseWebhookSinkConfig(raw string) (*WebhookSinkConfigModel, bool) {
+	var value interface{}
+	if json.Unmarshal([]byte(raw), &value) != nil {
+		return nil, false
+	}
+	fields, ok := jsonObjectFields(value, "Flush", "Retry")
+	if !ok {
+		return nil, false
+	}
+	config := WebhookSinkConfigModel{}
+	if v, set := fields["Flush"]; set {
+		if config.Flush, ok = parseSinkFlushConfig(v); !ok {
+			return nil, false
+		}
+	}
+	if v, set := fields["Retry"]; set {
+		retryFields, ok := jsonObjectFields(v, "Max", "Backoff")
+		if !ok {
+			return nil, false
+		}
+		config.Retry = &SinkRetryConfigModel{}
+		if max, set := retryFields["Max"]; set {
+			if maxNumber, ok := jsonInt64(max); ok {
+				config.Retry.Max = types.StringValue(strconv.FormatInt(maxNumber.ValueInt64(), 10))
+			} else if config.Retry.Max, ok = jsonString(max); !ok {
+				return nil, false
+			}
+		}
+		if backoff, set := retryFields["Backoff"]; set {
+			if config.Retry.Backoff, ok = jsonString(backoff); !ok {
+				return nil, false
+			}
+		}
+	}
+	return &config, true
+}
+
+func (f *SinkFlushConfigModel) isKnown() bool {
+	return f == nil || !(f.Messages.IsUnknown() || f.Bytes.IsUnknown() || f.Frequency.IsUnknown())
+}
+
+// IsKnown reports whether every value needed to render the JSON is known.
+func (c *KafkaSinkConfigModel) IsKnown() bool {
+	return c.Flush.isKnown() && !(c.RequiredAcks.IsUnknown() || c.Compression.IsUnknown() || c.ClientId.IsUnknown() || c.Version.IsUnknown())
+}
+
+// IsKnown reports whether every value needed to render the JSON is known.
+func (c *WebhookSinkConfigModel) IsKnown() bool {
+	return c.Flush.isKnown() && (c.Retry == nil || !(c.Retry.Max.IsUnknown() || c.Retry.Backoff.IsUnknown()))
+}
+
+// withRenderedSinkConfigs returns the options with the typed sink configs rendered into the JSON options.
+func (options ChangefeedOptionsModel) withRenderedSinkConfigs() ChangefeedOptionsModel {
+	if options.KafkaSinkSettings != nil {
+		options.KafkaSinkConfig = types.StringUnknown()
+		if options.KafkaSinkSettings.IsKnown() {
+			options.KafkaSinkConfig = types.StringValue(options.KafkaSinkSettings.Json())
+		}
+	}
+	if options.WebhookSinkSettings != nil {
+		options.WebhookSinkConfig = types.StringUnknown()
+		if options.WebhookSinkSettings.IsKnown() {
+			options.WebhookSinkConfig = types.StringValue(options.WebhookSinkSettings.Json())
+		}
+	}
+	return options
+}
+
+// reconcileSinkSettings updates the typed sink configs from the JSON options read from the changefeed.
+// The typed config is dropped in favour of the JSON option when the JSON cannot be represented.
+func (options *ChangefeedOptionsModel) reconcileSinkSettings(kafkaSinkSettings *KafkaSinkConfigModel, webhookSinkSettings *WebhookSinkConfigModel) {
+	if kafkaSinkSettings != nil {
+		switch {
+		case options.KafkaSinkConfig.IsNull():
+			options.KafkaSinkSettings = nil
+		case options.KafkaSinkConfig.ValueString() == kafkaSinkSettings.Json():
+			options.KafkaSinkSettings = kafkaSinkSettings
+			options.KafkaSinkConfig = types.StringNull()
+		default:
+			if parsed, ok := parseKafkaSinkConfig(options.KafkaSinkConfig.ValueString()); ok {
+				options.KafkaSinkSettings = parsed
+				options.KafkaSinkConfig = types.StringNull()
+			} else {
+				options.KafkaSinkSettings = nil
+			}
+		}
+	}
+
+	if webhookSinkSettings != nil {
+		switch {
+		case options.WebhookSinkConfig.IsNull():
+			options.WebhookSinkSettings = nil
+		case options.WebhookSinkConfig.ValueString() == webhookSinkSettings.Json():
+			options.WebhookSinkSettings = webhookSinkSettings
+			options.WebhookSinkConfig = types.StringNull()
+		default:
+			if parsed, ok := parseWebhookSinkConfig(options.WebhookSinkConfig.ValueString()); ok {
+				options.WebhookSinkSettings = parsed
+				options.WebhookSinkConfig = types.StringNull()
+			} else {
+				options.WebhookSinkSettings = nil
+			}
+		}
+	}
+}
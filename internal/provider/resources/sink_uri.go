@@ -0,0 +1,241 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sinkScheme struct {
+	// requiresHost is set when the host part (broker, bucket, project or connection name) is mandatory
+	requiresHost bool
+	required     []string
+	allowed      []string
+	// requiredTogether lists params that have to be set together
+	requiredTogether [][]string
+	// authRequires lists the params required by each value of the AUTH param
+	authRequires map[string][]string
+}
+
+var kafkaTopicParams = []string{"topic_name", "topic_prefix"}
+
+var cloudStorageParams = []string{"AUTH", "ASSUME_ROLE", "partition_format", "file_size", "topic_prefix"}
+
+var tlsParams = []string{"tls_enabled", "ca_cert", "client_cert", "client_key", "insecure_tls_skip_verify"}
+
+var sinkSchemes = map[string]sinkScheme{
+	"kafka": {
+		requiresHost: true,
+		allowed: slices.Concat(kafkaTopicParams, tlsParams, []string{
+			"sasl_enabled", "sasl_mechanism", "sasl_handshake", "sasl_user", "sasl_password",
+			"sasl_client_id", "sasl_client_secret", "sasl_token_url", "sasl_scopes", "sasl_grant_type",
+			"sasl_aws_region", "sasl_aws_iam_role_arn", "sasl_aws_iam_session_name",
+		}),
+		requiredTogether: [][]string{
+			{"sasl_user", "sasl_password"},
+			{"client_cert", "client_key"},
+		},
+	},
+	"confluent-cloud": {
+		requiresHost: true,
+		required:     []string{"api_key", "api_secret"},
+		allowed:      kafkaTopicParams,
+	},
+	"azure-event-hub": {
+		requiresHost: true,
+		required:     []string{"shared_access_key_name", "shared_access_key"},
+		allowed:      kafkaTopicParams,
+	},
+	"gcpubsub": {
+		requiresHost: true,
+		allowed:      []string{"region", "topic_name", "topic_prefix", "AUTH", "CREDENTIALS", "ASSUME_ROLE"},
+		authRequires: map[string][]string{"specified": {"CREDENTIALS"}, "implicit": {}},
+	},
+	"webhook-https": {
+		requiresHost: true,
+		allowed:      []string{"insecure_tls_skip_verify", "ca_cert", "client_cert", "client_key"},
+		requiredTogether: [][]string{
+			{"client_cert", "client_key"},
+		},
+	},
+	"s3": {
+		requiresHost: true,
+		allowed: slices.Concat(cloudStorageParams, []string{
+			"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_ENDPOINT", "AWS_REGION",
+			"AWS_USE_PATH_STYLE", "AWS_SKIP_CHECKSUM", "S3_STORAGE_CLASS",
+		}),
+		requiredTogether: [][]string{
+			{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"},
+		},
+		authRequires: map[string][]string{"specified": {"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}, "implicit": {}},
+	},
+	"gs": {
+		requiresHost: true,
+		allowed:      slices.Concat(cloudStorageParams, []string{"CREDENTIALS"}),
+		authRequires: map[string][]string{"specified": {"CREDENTIALS"}, "implicit": {}},
+	},
+	"azure": {
+		requiresHost: true,
+		required:     []string{"AZURE_ACCOUNT_NAME"},
+		allowed: slices.Concat(cloudStorageParams, []string{
+			"AZURE_ACCOUNT_KEY", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID", "AZURE_ENVIRONMENT",
+		}),
+		requiredTogether: [][]string{
+			{"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID"},
+		},
+	},
+	"external": {
+		requiresHost: true,
+	},
+}
+
+func sinkSchemeNames() []string {
+	names := make([]string, 0, len(sinkSchemes))
+	for name := range sinkSchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateSinkUri checks the scheme of the sink URI and the query params it allows and requires.
+// Values are never included in the error since they can be secrets.
+func validateSinkUri(sinkUri string) error {
+	parsedUri, err := url.Parse(sinkUri)
+	if err != nil {
+		return fmt.Errorf("sink URI cannot be parsed")
+	}
+
+	scheme, ok := sinkSchemes[parsedUri.Scheme]
+	if !ok {
+		return fmt.Errorf("unsupported sink scheme %q, expected one of %s", parsedUri.Scheme, strings.Join(sinkSchemeNames(), ", "))
+	}
+
+	if scheme.requiresHost && parsedUri.Host == "" {
+		return fmt.Errorf("%s sink URI requires a host", parsedUri.Scheme)
+	}
+
+	params := parsedUri.Query()
+	for key := range params {
+		if !slices.Contains(scheme.required, key) && !slices.Contains(scheme.allowed, key) {
+			return fmt.Errorf("unsupported query parameter %q for %s sink", key, parsedUri.Scheme)
+		}
+	}
+
+	for _, key := range scheme.required {
+		if !params.Has(key) {
+			return fmt.Errorf("%s sink URI requires the %q query parameter", parsedUri.Scheme, key)
+		}
+	}
+
+	for _, group := range scheme.requiredTogether {
+		set := 0
+		for _, key := range group {
+			if params.Has(key) {
+				set++
+			}
+		}
+		if set != 0 && set != len(group) {
+			return fmt.Errorf("%s sink URI query parameters %s have to be set together", parsedUri.Scheme, strings.Join(group, ", "))
+		}
+	}
+
+	if scheme.authRequires != nil && params.Has("AUTH") {
+		required, ok := scheme.authRequires[params.Get("AUTH")]
+		if !ok {
+			return fmt.Errorf("unsupported AUTH value for %s sink", parsedUri.Scheme)
+		}
+		for _, key := range required {
+			if !params.Has(key) {
+				return fmt.Errorf("%s sink URI with AUTH=%s requires the %q query parameter", parsedUri.Scheme, params.Get("AUTH"), key)
+			}
+		}
+	}
+
+	return nil
+}
+
+type sinkUriValidator struct{}
+
+func (v sinkUriValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v sinkUriValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a sink URI with one of the schemes " + strings.Join(sinkSchemeNames(), ", ") + " and the query parameters supported by it"
+}
+
+func (v sinkUriValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateSinkUri(request.ConfigValue.ValueString()); err != nil {
+		// The value is sensitive, only the reason is reported
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			err.Error(),
+		))
+	}
+}
+
+func SinkUriValidator() validator.String {
+	return sinkUriValidator{}
+}
+
+// ChangefeedSinkModel is the structured form of a sink URI.
+type ChangefeedSinkModel struct {
+	Type            types.String `tfsdk:"type"`
+	Host            types.String `tfsdk:"host"`
+	Path            types.String `tfsdk:"path"`
+	Params          types.Map    `tfsdk:"params"`
+	SensitiveParams types.Map    `tfsdk:"sensitive_params"`
+}
+
+// IsKnown reports whether every value needed to render the URI is known.
+func (s *ChangefeedSinkModel) IsKnown() bool {
+	if s.Type.IsUnknown() || s.Host.IsUnknown() || s.Path.IsUnknown() || s.Params.IsUnknown() || s.SensitiveParams.IsUnknown() {
+		return false
+	}
+	for _, params := range []types.Map{s.Params, s.SensitiveParams} {
+		for _, value := range params.Elements() {
+			if value.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Uri renders the sink as a URI, query params are sorted by key.
+func (s *ChangefeedSinkModel) Uri(ctx context.Context) string {
+	params := url.Values{}
+	for _, paramsMap := range []types.Map{s.Params, s.SensitiveParams} {
+		values := map[string]string{}
+		paramsMap.ElementsAs(ctx, &values, false)
+		for key, value := range values {
+			params.Set(key, value)
+		}
+	}
+
+	path := s.Path.ValueString()
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	sinkUri := url.URL{
+		Scheme:   s.Type.ValueString(),
+		Host:     s.Host.ValueString(),
+		Path:     path,
+		RawQuery: params.Encode(),
+	}
+	return sinkUri.String()
+}
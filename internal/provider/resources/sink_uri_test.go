@@ -0,0 +1,150 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateSinkUri(t *testing.T) {
+	tests := []struct {
+		name    string
+		sinkUri string
+		err     string
+	}{
+		{"kafka", "kafka://broker:9092?topic_prefix=cdc_&tls_enabled=true", ""},
+		{"kafka sasl", "kafka://broker:9092?sasl_enabled=true&sasl_user=user&sasl_password=secret", ""},
+		{"kafka partial sasl", "kafka://broker:9092?sasl_user=user", "have to be set together"},
+		{"kafka without host", "kafka://?topic_name=t", "requires a host"},
+		{"kafka unknown param", "kafka://broker:9092?api_key=key", `unsupported query parameter "api_key"`},
+		{"confluent cloud", "confluent-cloud://cluster:9092?api_key=key&api_secret=secret", ""},
+		{"confluent cloud missing secret", "confluent-cloud://cluster:9092?api_key=key", `requires the "api_secret" query parameter`},
+		{"s3 implicit", "s3://bucket/path?AUTH=implicit", ""},
+		{"s3 specified", "s3://bucket/path?AUTH=specified&AWS_ACCESS_KEY_ID=id&AWS_SECRET_ACCESS_KEY=secret", ""},
+		{"s3 specified missing keys", "s3://bucket/path?AUTH=specified", `requires the "AWS_ACCESS_KEY_ID" query parameter`},
+		{"s3 partial keys", "s3://bucket/path?AWS_ACCESS_KEY_ID=id", "have to be set together"},
+		{"s3 unknown auth", "s3://bucket/path?AUTH=guess", "unsupported AUTH value"},
+		{"gs specified missing credentials", "gs://bucket?AUTH=specified", `requires the "CREDENTIALS" query parameter`},
+		{"external connection", "external://sink", ""},
+		{"unknown scheme", "ftp://host", `unsupported sink scheme "ftp"`},
+		{"unparsable", "kafka://broker:port", "cannot be parsed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSinkUri(test.sinkUri)
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateSinkUriHidesValues(t *testing.T) {
+	err := validateSinkUri("confluent-cloud://cluster:9092?api_key=very-secret&unknown=also-secret")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error contains a param value: %s", err)
+	}
+}
+
+func stringMap(values map[string]string) types.Map {
+	if values == nil {
+		return types.MapNull(types.StringType)
+	}
+	elements := map[string]attr.Value{}
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestChangefeedSinkModelUri(t *testing.T) {
+	tests := []struct {
+		name            string
+		sinkType        string
+		host            string
+		path            types.String
+		params          map[string]string
+		sensitiveParams map[string]string
+		expected        string
+	}{
+		{
+			name:     "host only",
+			sinkType: "kafka",
+			host:     "broker:9092",
+			path:     types.StringNull(),
+			expected: "kafka://broker:9092",
+		},
+		{
+			name:            "params are merged and sorted",
+			sinkType:        "kafka",
+			host:            "broker:9092",
+			path:            types.StringNull(),
+			params:          map[string]string{"topic_prefix": "cdc_", "sasl_user": "user"},
+			sensitiveParams: map[string]string{"sasl_password": "p&ss"},
+			expected:        "kafka://broker:9092?sasl_password=p%26ss&sasl_user=user&topic_prefix=cdc_",
+		},
+		{
+			name:     "path without leading slash",
+			sinkType: "s3",
+			host:     "bucket",
+			path:     types.StringValue("changefeeds/orders"),
+			params:   map[string]string{"AUTH": "implicit"},
+			expected: "s3://bucket/changefeeds/orders?AUTH=implicit",
+		},
+		{
+			name:     "path with leading slash",
+			sinkType: "gs",
+			host:     "bucket",
+			path:     types.StringValue("/orders"),
+			expected: "gs://bucket/orders",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := ChangefeedSinkModel{
+				Type:            types.StringValue(test.sinkType),
+				Host:            types.StringValue(test.host),
+				Path:            test.path,
+				Params:          stringMap(test.params),
+				SensitiveParams: stringMap(test.sensitiveParams),
+			}
+			if !sink.IsKnown() {
+				t.Fatal("expected the sink to be known")
+			}
+			actual := sink.Uri(context.Background())
+			if actual != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, actual)
+			}
+			if err := validateSinkUri(actual); err != nil {
+				t.Errorf("rendered URI is invalid: %s", err)
+			}
+		})
+	}
+}
+
+func TestChangefeedSinkModelIsKnown(t *testing.T) {
+	sink := ChangefeedSinkModel{
+		Type:            types.StringValue("kafka"),
+		Host:            types.StringValue("broker:9092"),
+		Path:            types.StringNull(),
+		Params:          types.MapValueMust(types.StringType, map[string]attr.Value{"topic_name": types.StringUnknown()}),
+		SensitiveParams: types.MapNull(types.StringType),
+	}
+	if sink.IsKnown() {
+		t.Error("expected a sink with an unknown param to be unknown")
+	}
+}
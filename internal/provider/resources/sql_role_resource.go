@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -25,9 +27,10 @@ type SqlRoleResource struct {
 }
 
 type SqlRoleResourceModel struct {
-	ClusterId types.String `tfsdk:"cluster_id"`
-	RoleName  types.String `tfsdk:"name"`
-	Id        types.String `tfsdk:"id"`
+	ClusterId types.String   `tfsdk:"cluster_id"`
+	RoleName  types.String   `tfsdk:"name"`
+	Id        types.String   `tfsdk:"id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func buildSqlRoleId(clusterId string, username string) string {
@@ -73,6 +76,9 @@ func (r *SqlRoleResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional: false,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -100,8 +106,11 @@ func (r *SqlRoleResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("CREATE ROLE %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()))
+		_, err := db.ExecEx(ctx, fmt.Sprintf("CREATE ROLE %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()), nil)
 		return nil, err
 	})
 
@@ -124,9 +133,12 @@ func (r *SqlRoleResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	exists, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
 		var result bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", data.RoleName.ValueString()).Scan(&result)
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", nil, data.RoleName.ValueString()).Scan(&result)
 		return &result, err
 	})
 
@@ -147,7 +159,17 @@ func (r *SqlRoleResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *SqlRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("updating sql roles is not supported", "updating sql roles is not supported")
+	// Every other attribute requires replacement, so only the timeouts can change in place
+	var data SqlRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SqlRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -159,14 +181,17 @@ func (r *SqlRoleResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("REVOKE ALL ON * FROM %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()))
+		_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ALL ON * FROM %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()), nil)
 
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = db.Exec(fmt.Sprintf("DROP ROLE %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()))
+		_, err = db.ExecEx(ctx, fmt.Sprintf("DROP ROLE %s", pgx.Identifier{data.RoleName.ValueString()}.Sanitize()), nil)
 		return nil, err
 	})
 
@@ -187,7 +212,7 @@ func (r *SqlRoleResource) ImportState(ctx context.Context, req resource.ImportSt
 
 	exists, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
 		var result bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", username).Scan(&result)
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", nil, username).Scan(&result)
 		return &result, err
 	})
 
@@ -210,5 +235,7 @@ func (r *SqlRoleResource) ImportState(ctx context.Context, req resource.ImportSt
 	data.RoleName = types.StringValue(username)
 	data.Id = types.StringValue(req.ID)
 
+	data.Timeouts = nullTimeouts()
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,341 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+)
+
+var _ resource.Resource = &SqlScheduleResource{}
+var _ resource.ResourceWithImportState = &SqlScheduleResource{}
+
+func NewSqlScheduleResource() resource.Resource {
+	return &SqlScheduleResource{}
+}
+
+type SqlScheduleResource struct {
+	client *ccloud.CcloudClient
+}
+
+type SqlScheduleResourceModel struct {
+	ClusterId  types.String   `tfsdk:"cluster_id"`
+	Id         types.String   `tfsdk:"id"`
+	Label      types.String   `tfsdk:"label"`
+	Recurring  types.String   `tfsdk:"recurring"`
+	Paused     types.Bool     `tfsdk:"paused"`
+	ScheduleId types.Int64    `tfsdk:"schedule_id"`
+	State      types.String   `tfsdk:"state"`
+	NextRun    types.String   `tfsdk:"next_run"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// CockroachDB only supports CREATE SCHEDULE for backups and changefeeds. The SQL schedules it runs on its own are
+// created by the cluster and their recurrence is controlled by a cluster setting, ALTER SCHEDULE is not available for them.
+var systemScheduleRecurrenceSettings = map[string]string{
+	"sql-stats-compaction": "sql.stats.cleanup.recurrence",
+	"sql-schema-telemetry": "sql.schema.telemetry.recurrence",
+}
+
+func systemScheduleLabels() []string {
+	labels := make([]string, 0, len(systemScheduleRecurrenceSettings))
+	for label := range systemScheduleRecurrenceSettings {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+func getSqlScheduleId(clusterId string, label string) string {
+	return fmt.Sprintf("sql_schedule|%s|%s", clusterId, label)
+}
+
+func (r *SqlScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sql_schedule"
+}
+
+func (r *SqlScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Recurring SQL schedule managed by the cluster, like ` + "`sql-stats-compaction`" + `.
+CockroachDB does not support ` + "`CREATE SCHEDULE`" + ` for arbitrary SQL statements, so this resource adopts one of the system schedules
+and manages its recurrence and whether it is paused.
+Deleting the resource resets the recurrence to the cluster default and resumes the schedule.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the schedule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Label of the system schedule. One of " + "`" + strings.Join(systemScheduleLabels(), "`, `") + "`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(systemScheduleLabels()...),
+				},
+			},
+			"recurring": schema.StringAttribute{
+				MarkdownDescription: "Recurring schedule. Defaults to the current recurrence of the schedule",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf("@daily", "@hourly", "@weekly"),
+						CronExpressionValidator(),
+					),
+				},
+			},
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Whether the schedule is paused",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"schedule_id": schema.Int64Attribute{
+				MarkdownDescription: "Schedule ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the schedule as reported by `SHOW SCHEDULES`",
+				Computed:            true,
+			},
+			"next_run": schema.StringAttribute{
+				MarkdownDescription: "Next time the schedule runs, empty when the schedule is paused",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *SqlScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+// applySqlSchedule sets the recurrence and paused state of the schedule and returns its current status.
+func (r *SqlScheduleResource) applySqlSchedule(ctx context.Context, data *SqlScheduleResourceModel) error {
+	recurrenceSetting := systemScheduleRecurrenceSettings[data.Label.ValueString()]
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		schedule, err := getScheduleStatusByLabel(ctx, db, data.Label.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		if !data.Recurring.IsUnknown() && !data.Recurring.IsNull() {
+			tflog.Debug(ctx, fmt.Sprintf("Setting %s to %s", recurrenceSetting, data.Recurring.ValueString()))
+			if err := execSetClusterSetting(ctx, db, recurrenceSetting, data.Recurring.ValueString()); err != nil {
+				return nil, err
+			}
+		}
+
+		if schedule.Paused() != data.Paused.ValueBool() {
+			if err := execSetSchedulePaused(ctx, db, schedule.Id, data.Paused.ValueBool()); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.readSqlSchedule(ctx, data)
+}
+
+func (r *SqlScheduleResource) readSqlSchedule(ctx context.Context, data *SqlScheduleResourceModel) error {
+	recurrenceSetting, ok := systemScheduleRecurrenceSettings[data.Label.ValueString()]
+	if !ok {
+		return fmt.Errorf("unsupported schedule %s, expected one of %s", data.Label.ValueString(), strings.Join(systemScheduleLabels(), ", "))
+	}
+
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		schedule, err := getScheduleStatusByLabel(ctx, db, data.Label.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		// The schedule picks up a new recurrence asynchronously, the cluster setting is the source of truth
+		setting, err := getClusterSettingInfo(ctx, db, recurrenceSetting)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Recurring = types.StringValue(setting.Value)
+		data.Paused = types.BoolValue(schedule.Paused())
+		data.ScheduleId = types.Int64Value(schedule.Id)
+		data.State = schedule.StateValue()
+		data.NextRun = schedule.NextRunValue()
+
+		return nil, nil
+	})
+
+	return err
+}
+
+func (r *SqlScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SqlScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.applySqlSchedule(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to create sql schedule", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(getSqlScheduleId(data.ClusterId.ValueString(), data.Label.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SqlScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SqlScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.readSqlSchedule(ctx, &data); err != nil {
+		var notFound ScheduleNotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read sql schedule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SqlScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SqlScheduleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.applySqlSchedule(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to update sql schedule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SqlScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SqlScheduleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	// System schedules cannot be dropped, restore the cluster defaults instead
+	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		if err := execResetClusterSetting(ctx, db, systemScheduleRecurrenceSettings[data.Label.ValueString()]); err != nil {
+			return nil, err
+		}
+
+		schedule, err := getScheduleStatusByLabel(ctx, db, data.Label.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		if schedule.Paused() {
+			return nil, execSetSchedulePaused(ctx, db, schedule.Id, false)
+		}
+		return nil, nil
+	})
+
+	if err != nil {
+		var notFound ScheduleNotFoundError
+		if errors.As(err, &notFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Unable to delete sql schedule", err.Error())
+		return
+	}
+}
+
+func (r *SqlScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, "|")
+	if len(idParts) != 3 || idParts[0] != "sql_schedule" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format sql_schedule|<cluster_id>|<label>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("label"), idParts[2])...)
+}
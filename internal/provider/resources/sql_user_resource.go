@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -40,10 +42,11 @@ func parseSqlUserId(id string) (clusterId string, username string, err error) {
 }
 
 type SqlUserResourceModel struct {
-	ClusterId types.String `tfsdk:"cluster_id"`
-	Username  types.String `tfsdk:"name"`
-	Password  types.String `tfsdk:"password"`
-	Id        types.String `tfsdk:"id"`
+	ClusterId types.String   `tfsdk:"cluster_id"`
+	Username  types.String   `tfsdk:"name"`
+	Password  types.String   `tfsdk:"password"`
+	Id        types.String   `tfsdk:"id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SqlUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,6 +82,9 @@ func (r *SqlUserResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional: false,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -106,12 +112,15 @@ func (r *SqlUserResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
 		if data.Password.IsNull() {
-			_, err := db.Exec(fmt.Sprintf("CREATE USER %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()))
+			_, err := db.ExecEx(ctx, fmt.Sprintf("CREATE USER %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil)
 			return nil, err
 		} else {
-			_, err := db.Exec(fmt.Sprintf("CREATE USER %s WITH PASSWORD $1", pgx.Identifier{data.Username.ValueString()}.Sanitize()), data.Password.ValueString())
+			_, err := db.ExecEx(ctx, fmt.Sprintf("CREATE USER %s WITH PASSWORD $1", pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil, data.Password.ValueString())
 			return nil, err
 		}
 	})
@@ -135,9 +144,12 @@ func (r *SqlUserResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
 	exists, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
 		var result bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", data.Username.ValueString()).Scan(&result)
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", nil, data.Username.ValueString()).Scan(&result)
 		return &result, err
 	})
 
@@ -162,13 +174,17 @@ func (r *SqlUserResource) Update(ctx context.Context, req resource.UpdateRequest
 	var data SqlUserResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("ALTER USER %s WITH PASSWORD $1", pgx.Identifier{data.Username.ValueString()}.Sanitize()), data.Password.ValueString())
+		_, err := db.ExecEx(ctx, fmt.Sprintf("ALTER USER %s WITH PASSWORD $1", pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil, data.Password.ValueString())
 		return nil, err
 	})
 
@@ -189,14 +205,17 @@ func (r *SqlUserResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
 	_, err := ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
-		_, err := db.Exec(fmt.Sprintf("REVOKE ALL ON * FROM %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()))
+		_, err := db.ExecEx(ctx, fmt.Sprintf("REVOKE ALL ON * FROM %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil)
 
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = db.Exec(fmt.Sprintf("DROP USER %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()))
+		_, err = db.ExecEx(ctx, fmt.Sprintf("DROP USER %s", pgx.Identifier{data.Username.ValueString()}.Sanitize()), nil)
 		return nil, err
 	})
 
@@ -217,7 +236,7 @@ func (r *SqlUserResource) ImportState(ctx context.Context, req resource.ImportSt
 
 	exists, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*bool, error) {
 		var result bool
-		err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", username).Scan(&result)
+		err := db.QueryRowEx(ctx, "SELECT EXISTS(SELECT 1 FROM [SHOW USERS] WHERE username = $1)", nil, username).Scan(&result)
 		return &result, err
 	})
 
@@ -241,5 +260,7 @@ func (r *SqlUserResource) ImportState(ctx context.Context, req resource.ImportSt
 	data.Password = types.StringValue("")
 	data.Id = types.StringValue(req.ID)
 
+	data.Timeouts = nullTimeouts()
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,634 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/parser"
+	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jackc/pgx"
+	"github.com/nrfcloud/terraform-provider-cockroach-extra/internal/provider/ccloud"
+	"gopkg.in/yaml.v3"
+)
+
+var _ resource.Resource = &ZoneConfigResource{}
+var _ resource.ResourceWithImportState = &ZoneConfigResource{}
+var _ resource.ResourceWithConfigValidators = &ZoneConfigResource{}
+
+func NewZoneConfigResource() resource.Resource {
+	return &ZoneConfigResource{}
+}
+
+type ZoneConfigResource struct {
+	client *ccloud.CcloudClient
+}
+
+type ZoneConfigResourceModel struct {
+	ClusterId             types.String   `tfsdk:"cluster_id"`
+	Target                types.String   `tfsdk:"target"`
+	NumReplicas           types.Int64    `tfsdk:"num_replicas"`
+	GcTtlSeconds          types.Int64    `tfsdk:"gc_ttl_seconds"`
+	Constraints           types.List     `tfsdk:"constraints"`
+	ConstraintsPerReplica types.Map      `tfsdk:"constraints_per_replica"`
+	LeasePreferences      types.List     `tfsdk:"lease_preferences"`
+	RangeMinBytes         types.Int64    `tfsdk:"range_min_bytes"`
+	RangeMaxBytes         types.Int64    `tfsdk:"range_max_bytes"`
+	GlobalReads           types.Bool     `tfsdk:"global_reads"`
+	Id                    types.String   `tfsdk:"id"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func buildZoneConfigId(clusterId string, target string) string {
+	return fmt.Sprintf("zone_config|%s|%s", clusterId, target)
+}
+
+// parseZoneTarget parses the part of a zone configuration statement that follows ALTER, e.g. TABLE db.public.t.
+func parseZoneTarget(target string) (*tree.ZoneSpecifier, error) {
+	statement, err := parser.ParseOne("SHOW ZONE CONFIGURATION FROM " + target)
+	if err != nil {
+		return nil, err
+	}
+	showStatement, ok := statement.AST.(*tree.ShowZoneConfig)
+	if !ok || showStatement.ZoneSpecifier == (tree.ZoneSpecifier{}) {
+		return nil, fmt.Errorf("invalid zone target %s", target)
+	}
+	return &showStatement.ZoneSpecifier, nil
+}
+
+type zoneConfigOptionsValidator struct {
+	resource.ConfigValidator
+}
+
+func (v *zoneConfigOptionsValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v *zoneConfigOptionsValidator) MarkdownDescription(ctx context.Context) string {
+	return "At least one zone configuration option must be set"
+}
+
+func (v *zoneConfigOptionsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	for _, value := range data.optionValues() {
+		if !value.IsNull() {
+			return
+		}
+	}
+	resp.Diagnostics.AddError("At least one zone configuration option must be set", "")
+}
+
+func (r *ZoneConfigResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		&zoneConfigOptionsValidator{},
+	}
+}
+
+func (r *ZoneConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_config"
+}
+
+func (r *ZoneConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Zone configuration of a range, database, table, index or partition.
+Only the configured options are managed, every other option keeps being inherited from the parent zone.
+Destroying the resource discards the zone configuration.
+`,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "Cluster ID",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.StringAttribute{
+				MarkdownDescription: "Zone to configure, in the form used by `ALTER ... CONFIGURE ZONE`. For example `RANGE default`, `DATABASE db`, `TABLE db.public.t`, `INDEX db.public.t@idx` or `PARTITION p OF INDEX db.public.t@idx`. Tables and indexes must be fully qualified",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					ZoneTargetValidator(),
+				},
+			},
+			"num_replicas": schema.Int64Attribute{
+				MarkdownDescription: "Number of replicas for each range",
+				Optional:            true,
+			},
+			"gc_ttl_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds overwritten values are retained before garbage collection (`gc.ttlseconds`). Persistent cursors can only resume a changefeed within this window.",
+				Optional:            true,
+			},
+			"constraints": schema.ListAttribute{
+				MarkdownDescription: "Constraints that apply to every replica, e.g. `+region=us-east1`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("constraints_per_replica")),
+				},
+			},
+			"constraints_per_replica": schema.MapAttribute{
+				MarkdownDescription: "Map of comma separated constraints to the number of replicas they apply to, e.g. `{ \"+region=us-east1\" = 1 }`",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("constraints")),
+				},
+			},
+			"lease_preferences": schema.ListAttribute{
+				MarkdownDescription: "Ordered list of lease preferences, each a list of constraints, e.g. `[[\"+region=us-east1\"]]`",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"range_min_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Minimum range size in bytes",
+				Optional:            true,
+			},
+			"range_max_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum range size in bytes",
+				Optional:            true,
+			},
+			"global_reads": schema.BoolAttribute{
+				MarkdownDescription: "Serve consistent reads from every replica at the cost of slower writes",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Zone config ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ZoneConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ccloud.CcloudClient)
+
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type",
+			fmt.Sprintf("Expected *CcloudClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+// optionValues returns every zone config option attribute keyed by its zone config option name.
+// constraints and constraints_per_replica share the constraints option.
+func (data *ZoneConfigResourceModel) optionValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"num_replicas":            data.NumReplicas,
+		"gc.ttlseconds":           data.GcTtlSeconds,
+		"constraints":             data.Constraints,
+		"constraints_per_replica": data.ConstraintsPerReplica,
+		"lease_preferences":       data.LeasePreferences,
+		"range_min_bytes":         data.RangeMinBytes,
+		"range_max_bytes":         data.RangeMaxBytes,
+		"global_reads":            data.GlobalReads,
+	}
+}
+
+func int64ZoneConfigValue(value types.Int64) tree.Expr {
+	if value.IsNull() {
+		return nil
+	}
+	return tree.NewDInt(tree.DInt(value.ValueInt64()))
+}
+
+// jsonZoneConfigValue renders a list or map option as a JSON string, which the cluster accepts as YAML.
+func jsonZoneConfigValue(value any) (tree.Expr, error) {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewStrVal(string(valueBytes)), nil
+}
+
+// buildOptions builds the CONFIGURE ZONE options for data. Options set in previous but not in data are copied from the parent zone.
+func (data *ZoneConfigResourceModel) buildOptions(ctx context.Context, previous *ZoneConfigResourceModel) (tree.KVOptions, error) {
+	values := map[string]tree.Expr{
+		"num_replicas":    int64ZoneConfigValue(data.NumReplicas),
+		"gc.ttlseconds":   int64ZoneConfigValue(data.GcTtlSeconds),
+		"range_min_bytes": int64ZoneConfigValue(data.RangeMinBytes),
+		"range_max_bytes": int64ZoneConfigValue(data.RangeMaxBytes),
+	}
+
+	if !data.GlobalReads.IsNull() {
+		values["global_reads"] = tree.MakeDBool(tree.DBool(data.GlobalReads.ValueBool()))
+	}
+
+	if !data.Constraints.IsNull() {
+		var constraints []string
+		if diags := data.Constraints.ElementsAs(ctx, &constraints, false); diags.HasError() {
+			return nil, fmt.Errorf("unable to read constraints")
+		}
+		value, err := jsonZoneConfigValue(constraints)
+		if err != nil {
+			return nil, err
+		}
+		values["constraints"] = value
+	} else if !data.ConstraintsPerReplica.IsNull() {
+		constraints := map[string]int64{}
+		if diags := data.ConstraintsPerReplica.ElementsAs(ctx, &constraints, false); diags.HasError() {
+			return nil, fmt.Errorf("unable to read constraints_per_replica")
+		}
+		value, err := jsonZoneConfigValue(constraints)
+		if err != nil {
+			return nil, err
+		}
+		values["constraints"] = value
+	}
+
+	if !data.LeasePreferences.IsNull() {
+		var leasePreferences [][]string
+		if diags := data.LeasePreferences.ElementsAs(ctx, &leasePreferences, false); diags.HasError() {
+			return nil, fmt.Errorf("unable to read lease_preferences")
+		}
+		value, err := jsonZoneConfigValue(leasePreferences)
+		if err != nil {
+			return nil, err
+		}
+		values["lease_preferences"] = value
+	}
+
+	previouslySet := map[string]bool{}
+	if previous != nil {
+		for key, value := range previous.optionValues() {
+			if key == "constraints_per_replica" {
+				key = "constraints"
+			}
+			previouslySet[key] = previouslySet[key] || !value.IsNull()
+		}
+	}
+
+	var options tree.KVOptions
+	for _, key := range []string{"num_replicas", "gc.ttlseconds", "constraints", "lease_preferences", "range_min_bytes", "range_max_bytes", "global_reads"} {
+		value := values[key]
+		if value == nil && !previouslySet[key] {
+			continue
+		}
+		// A nil value renders as COPY FROM PARENT
+		options = append(options, tree.KVOption{Key: tree.Name(key), Value: value})
+	}
+	return options, nil
+}
+
+func (r *ZoneConfigResource) configureZone(ctx context.Context, data *ZoneConfigResourceModel, previous *ZoneConfigResourceModel) error {
+	zone, err := parseZoneTarget(data.Target.ValueString())
+	if err != nil {
+		return err
+	}
+
+	options, err := data.buildOptions(ctx, previous)
+	if err != nil {
+		return err
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+
+	statement := tree.SetZoneConfig{
+		ZoneSpecifier: *zone,
+		ZoneConfigSettings: tree.ZoneConfigSettings{
+			Options: options,
+		},
+	}
+	query := statement.String()
+
+	tflog.Info(ctx, fmt.Sprintf("Configuring zone with query: %s", query))
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, query, nil)
+		return nil, err
+	})
+	return err
+}
+
+type ZoneConfigNotFoundError struct {
+	Target string
+}
+
+func (e ZoneConfigNotFoundError) Error() string {
+	return fmt.Sprintf("no zone configuration set for %s", e.Target)
+}
+
+// getZoneConfigOptions returns the options of the zone configuration that applies to target.
+func (r *ZoneConfigResource) getZoneConfigOptions(ctx context.Context, clusterId string, target string) (map[string]tree.Expr, error) {
+	zone, err := parseZoneTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConfig, err := ccloud.SqlConWithTempUser(ctx, r.client, clusterId, "defaultdb", func(db *pgx.ConnPool) (*[2]string, error) {
+		var row [2]string
+		err := db.QueryRowEx(ctx, fmt.Sprintf("SELECT target, raw_config_sql FROM [SHOW ZONE CONFIGURATION FROM %s]", zone.String()), nil).Scan(&row[0], &row[1])
+		if err != nil {
+			return nil, err
+		}
+		return &row, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Zones without a configuration of their own show the configuration inherited from their parent
+	effectiveZone, err := parseZoneTarget(rawConfig[0])
+	if err != nil {
+		return nil, err
+	}
+	if tree.AsString(effectiveZone) != tree.AsString(zone) {
+		return nil, ZoneConfigNotFoundError{Target: target}
+	}
+
+	statement, err := parser.ParseOne(rawConfig[1])
+	if err != nil {
+		return nil, err
+	}
+	setStatement, ok := statement.AST.(*tree.SetZoneConfig)
+	if !ok {
+		return nil, fmt.Errorf("unexpected zone configuration statement: %s", rawConfig[1])
+	}
+
+	options := map[string]tree.Expr{}
+	for _, option := range setStatement.Options {
+		options[string(option.Key)] = option.Value
+	}
+	return options, nil
+}
+
+func zoneConfigString(value tree.Expr) string {
+	if str, ok := value.(*tree.StrVal); ok {
+		return str.RawString()
+	}
+	return removeQuotes(value.String())
+}
+
+func zoneConfigInt64(value tree.Expr) (types.Int64, error) {
+	i, err := strconv.ParseInt(zoneConfigString(value), 10, 64)
+	if err != nil {
+		return types.Int64Null(), fmt.Errorf("invalid integer zone config value %s", value.String())
+	}
+	return types.Int64Value(i), nil
+}
+
+// applyOptions updates data from the zone configuration options. When all is false only attributes that are already set are updated.
+func (data *ZoneConfigResourceModel) applyOptions(options map[string]tree.Expr, all bool) error {
+	int64Options := map[string]*types.Int64{
+		"num_replicas":    &data.NumReplicas,
+		"gc.ttlseconds":   &data.GcTtlSeconds,
+		"range_min_bytes": &data.RangeMinBytes,
+		"range_max_bytes": &data.RangeMaxBytes,
+	}
+	for key, attribute := range int64Options {
+		if !all && attribute.IsNull() {
+			continue
+		}
+		value, ok := options[key]
+		if !ok {
+			*attribute = types.Int64Null()
+			continue
+		}
+		i, err := zoneConfigInt64(value)
+		if err != nil {
+			return err
+		}
+		*attribute = i
+	}
+
+	if all || !data.GlobalReads.IsNull() {
+		value, ok := options["global_reads"]
+		data.GlobalReads = types.BoolValue(ok && strings.EqualFold(zoneConfigString(value), "true"))
+		if all && !data.GlobalReads.ValueBool() {
+			data.GlobalReads = types.BoolNull()
+		}
+	}
+
+	if value, ok := options["constraints"]; ok && (all || !data.Constraints.IsNull() || !data.ConstraintsPerReplica.IsNull()) {
+		var constraints any
+		if err := yaml.Unmarshal([]byte(zoneConfigString(value)), &constraints); err != nil {
+			return fmt.Errorf("invalid constraints %s: %w", value.String(), err)
+		}
+
+		data.Constraints = types.ListNull(types.StringType)
+		data.ConstraintsPerReplica = types.MapNull(types.Int64Type)
+
+		switch constraints := constraints.(type) {
+		case []any:
+			if all && len(constraints) == 0 {
+				break
+			}
+			values := make([]attr.Value, len(constraints))
+			for i, constraint := range constraints {
+				values[i] = types.StringValue(fmt.Sprint(constraint))
+			}
+			data.Constraints, _ = types.ListValue(types.StringType, values)
+		case map[string]any:
+			values := map[string]attr.Value{}
+			for constraint, numReplicas := range constraints {
+				n, ok := numReplicas.(int)
+				if !ok {
+					return fmt.Errorf("invalid replica count for constraint %s", constraint)
+				}
+				values[constraint] = types.Int64Value(int64(n))
+			}
+			data.ConstraintsPerReplica, _ = types.MapValue(types.Int64Type, values)
+		}
+	}
+
+	if value, ok := options["lease_preferences"]; ok && (all || !data.LeasePreferences.IsNull()) {
+		var leasePreferences [][]string
+		if err := yaml.Unmarshal([]byte(zoneConfigString(value)), &leasePreferences); err != nil {
+			return fmt.Errorf("invalid lease preferences %s: %w", value.String(), err)
+		}
+
+		data.LeasePreferences = types.ListNull(types.ListType{ElemType: types.StringType})
+		if !all || len(leasePreferences) > 0 {
+			values := make([]attr.Value, len(leasePreferences))
+			for i, preference := range leasePreferences {
+				constraints := make([]attr.Value, len(preference))
+				for j, constraint := range preference {
+					constraints[j] = types.StringValue(constraint)
+				}
+				values[i], _ = types.ListValue(types.StringType, constraints)
+			}
+			data.LeasePreferences, _ = types.ListValue(types.ListType{ElemType: types.StringType}, values)
+		}
+	}
+
+	return nil
+}
+
+func (r *ZoneConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.configureZone(ctx, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Unable to configure zone", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(buildZoneConfigId(data.ClusterId.ValueString(), data.Target.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	options, err := r.getZoneConfigOptions(ctx, data.ClusterId.ValueString(), data.Target.ValueString())
+	if err != nil {
+		var notFound ZoneConfigNotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, &ccloud.CockroachCloudClusterNotReadyError{}) || errors.Is(err, &ccloud.CockroachCloudClusterNotFoundError{}) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read zone configuration", err.Error())
+		return
+	}
+
+	if err := data.applyOptions(options, false); err != nil {
+		resp.Diagnostics.AddError("Unable to read zone configuration", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ZoneConfigResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+
+	if err := r.configureZone(ctx, &plan, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to configure zone", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ZoneConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneConfigResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+
+	zone, err := parseZoneTarget(data.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to discard zone configuration", err.Error())
+		return
+	}
+
+	if zone.NamedZone == "default" {
+		resp.Diagnostics.AddWarning("The default zone configuration cannot be discarded",
+			"The default zone configuration is left as is and no longer managed by terraform")
+		return
+	}
+
+	statement := tree.SetZoneConfig{
+		ZoneSpecifier: *zone,
+		ZoneConfigSettings: tree.ZoneConfigSettings{
+			YAMLConfig: tree.DNull,
+		},
+	}
+
+	_, err = ccloud.SqlConWithTempUser(ctx, r.client, data.ClusterId.ValueString(), "defaultdb", func(db *pgx.ConnPool) (*interface{}, error) {
+		_, err := db.ExecEx(ctx, statement.String(), nil)
+		return nil, err
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to discard zone configuration", err.Error())
+		return
+	}
+}
+
+func (r *ZoneConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.SplitN(req.ID, "|", 3)
+	if len(idParts) != 3 || idParts[0] != "zone_config" {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected ID to be in the format zone_config|<cluster_id>|<target>, got: %s", req.ID))
+		return
+	}
+
+	data := ZoneConfigResourceModel{
+		ClusterId:             types.StringValue(idParts[1]),
+		Target:                types.StringValue(idParts[2]),
+		Constraints:           types.ListNull(types.StringType),
+		ConstraintsPerReplica: types.MapNull(types.Int64Type),
+		LeasePreferences:      types.ListNull(types.ListType{ElemType: types.StringType}),
+		Id:                    types.StringValue(req.ID),
+		Timeouts:              nullTimeouts(),
+	}
+
+	options, err := r.getZoneConfigOptions(ctx, data.ClusterId.ValueString(), data.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read zone configuration", err.Error())
+		return
+	}
+
+	if err := data.applyOptions(options, true); err != nil {
+		resp.Diagnostics.AddError("Unable to read zone configuration", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type zoneTargetValidator struct{}
+
+func (v zoneTargetValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v zoneTargetValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a zone target like RANGE default, DATABASE db, TABLE db.schema.table, INDEX db.schema.table@index or PARTITION p OF TABLE db.schema.table"
+}
+
+func (v zoneTargetValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue
+
+	// Tables and indexes are compared with the fully qualified target reported by SHOW ZONE CONFIGURATION
+	zone, err := parseZoneTarget(value.ValueString())
+	if err != nil || (zone.TargetsTable() && !(zone.TableOrIndex.Table.ExplicitCatalog && zone.TableOrIndex.Table.ExplicitSchema)) {
+		response.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			request.Path,
+			v.Description(ctx),
+			value.String(),
+		))
+	}
+}
+
+func ZoneTargetValidator() validator.String {
+	return zoneTargetValidator{}
+}